@@ -0,0 +1,27 @@
+package dorklang
+
+type DivisionByZeroMode int
+
+const (
+	DivisionByZeroError DivisionByZeroMode = iota
+	DivisionByZeroZero
+	DivisionByZeroMax
+)
+
+type OverflowMode int
+
+const (
+	OverflowWrap OverflowMode = iota
+	OverflowSaturate
+	OverflowTrap
+)
+
+// the zero value is the full width of a cell, so options that do not set it are unchanged
+type CellWidth int
+
+const (
+	CellWidth64 CellWidth = iota
+	CellWidth32
+	CellWidth16
+	CellWidth8
+)
@@ -0,0 +1,53 @@
+package dorklang
+
+import (
+	"fmt"
+	"strings"
+)
+
+func ParseDivisionByZeroMode(s string) (mode DivisionByZeroMode, err error) {
+	switch strings.ToLower(s) {
+	case "error":
+		mode = DivisionByZeroError
+	case "zero":
+		mode = DivisionByZeroZero
+	case "max":
+		mode = DivisionByZeroMax
+	default:
+		err = ErrDivisionByZeroModeUnrecognized
+	}
+
+	return
+}
+
+func ParseOverflowMode(s string) (mode OverflowMode, err error) {
+	switch strings.ToLower(s) {
+	case "wrap":
+		mode = OverflowWrap
+	case "saturate":
+		mode = OverflowSaturate
+	case "trap":
+		mode = OverflowTrap
+	default:
+		err = fmt.Errorf("%w: %q", ErrOverflowModeUnrecognized, s)
+	}
+
+	return
+}
+
+func ParseCellWidth(s string) (width CellWidth, err error) {
+	switch s {
+	case "64":
+		width = CellWidth64
+	case "32":
+		width = CellWidth32
+	case "16":
+		width = CellWidth16
+	case "8":
+		width = CellWidth8
+	default:
+		err = fmt.Errorf("%w: %q", ErrCellWidthUnrecognized, s)
+	}
+
+	return
+}
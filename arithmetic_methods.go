@@ -0,0 +1,154 @@
+package dorklang
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+func (mode DivisionByZeroMode) String() string {
+	switch mode {
+	case DivisionByZeroError:
+		return "error"
+	case DivisionByZeroZero:
+		return "zero"
+	case DivisionByZeroMax:
+		return "max"
+	}
+
+	return "unknown"
+}
+
+func (mode DivisionByZeroMode) divide(dividend, divisor, max memoryCell) (quotient memoryCell, err error) {
+	if divisor != 0 {
+		quotient = dividend / divisor
+		return
+	}
+
+	switch mode {
+	case DivisionByZeroZero:
+		quotient = 0
+	case DivisionByZeroMax:
+		quotient = max
+	default:
+		err = fmt.Errorf("%w: %d / 0", ErrDivisionByZero, dividend)
+	}
+
+	return
+}
+
+// when dividing by zero is allowed, the remainder is the dividend, so that quotient * divisor + remainder still equals it
+func (mode DivisionByZeroMode) modulo(dividend, divisor memoryCell) (remainder memoryCell, err error) {
+	if divisor != 0 {
+		remainder = dividend % divisor
+		return
+	}
+
+	switch mode {
+	case DivisionByZeroZero,
+		DivisionByZeroMax:
+		remainder = dividend
+	default:
+		err = fmt.Errorf("%w: %d %% 0", ErrDivisionByZero, dividend)
+	}
+
+	return
+}
+
+func (mode OverflowMode) String() string {
+	switch mode {
+	case OverflowWrap:
+		return "wrap"
+	case OverflowSaturate:
+		return "saturate"
+	case OverflowTrap:
+		return "trap"
+	}
+
+	return "unknown"
+}
+
+// the operands are never greater than max, which is the largest value that a cell can hold
+func (mode OverflowMode) add(augend, addend, max memoryCell) (sum memoryCell, err error) {
+	result, carry := bits.Add64(uint64(augend), uint64(addend), 0)
+	sum = memoryCell(result) & max
+
+	if carry != 0 || memoryCell(result) > max {
+		switch mode {
+		case OverflowSaturate:
+			sum = max
+		case OverflowTrap:
+			err = fmt.Errorf("%w: %d + %d", ErrOverflow, augend, addend)
+		}
+	}
+
+	return
+}
+
+func (mode OverflowMode) subtract(minuend, subtrahend, max memoryCell) (difference memoryCell, err error) {
+	result, borrow := bits.Sub64(uint64(minuend), uint64(subtrahend), 0)
+	difference = memoryCell(result) & max
+
+	if borrow != 0 {
+		switch mode {
+		case OverflowSaturate:
+			difference = 0
+		case OverflowTrap:
+			err = fmt.Errorf("%w: %d - %d", ErrOverflow, minuend, subtrahend)
+		}
+	}
+
+	return
+}
+
+func (mode OverflowMode) multiply(multiplier, multiplicand, max memoryCell) (product memoryCell, err error) {
+	high, low := bits.Mul64(uint64(multiplier), uint64(multiplicand))
+	product = memoryCell(low) & max
+
+	if high != 0 || memoryCell(low) > max {
+		switch mode {
+		case OverflowSaturate:
+			product = max
+		case OverflowTrap:
+			err = fmt.Errorf("%w: %d * %d", ErrOverflow, multiplier, multiplicand)
+		}
+	}
+
+	return
+}
+
+func (width CellWidth) String() string {
+	switch width {
+	case CellWidth64:
+		return "64"
+	case CellWidth32:
+		return "32"
+	case CellWidth16:
+		return "16"
+	case CellWidth8:
+		return "8"
+	}
+
+	return "unknown"
+}
+
+func (width CellWidth) bits() int {
+	switch width {
+	case CellWidth32:
+		return 32
+	case CellWidth16:
+		return 16
+	case CellWidth8:
+		return 8
+	}
+
+	return 64
+}
+
+func (width CellWidth) max() memoryCell {
+	return math.MaxUint64 >> (64 - width.bits())
+}
+
+func (width CellWidth) truncate(cell memoryCell) memoryCell {
+	return cell & width.max()
+}
@@ -0,0 +1,16 @@
+package dorklang
+
+import "math/big"
+
+const (
+	bigCellFlag               memoryCell = 1 << 63 // set on cells that refer to an entry in the table, rather than holding a value
+	bigCellTableCollectMinLen            = 1 << 12 // 4_096
+	bigCellShiftMaxLen                   = 1 << 24 // 16_777_216
+)
+
+// in big-integer mode, values that do not fit into the lower 63 bits of a cell are kept in a table,
+// so commands that only move cells around work in the same way in both modes
+type bigCellTable struct {
+	cells     []*big.Int
+	collectAt int
+}
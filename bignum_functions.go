@@ -0,0 +1,7 @@
+package dorklang
+
+func newBigCellTable() *bigCellTable {
+	return &bigCellTable{
+		collectAt: bigCellTableCollectMinLen,
+	}
+}
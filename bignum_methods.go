@@ -0,0 +1,560 @@
+package dorklang
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"os"
+	"sort"
+	"unicode/utf8"
+
+	hash "github.com/theTardigrade/golang-hash"
+)
+
+func (table *bigCellTable) box(n *big.Int) memoryCell {
+	if n.Sign() >= 0 && n.IsUint64() && n.Uint64() < uint64(bigCellFlag) {
+		return memoryCellFromIntegerConstraint(n.Uint64())
+	}
+
+	table.cells = append(table.cells, n)
+
+	return bigCellFlag | memoryCellFromIntegerConstraint(len(table.cells)-1)
+}
+
+// the value that is returned must not be changed, since it can be shared by several cells
+func (table *bigCellTable) unbox(cell memoryCell) *big.Int {
+	if cell&bigCellFlag == 0 {
+		return new(big.Int).SetUint64(cell.Uint64())
+	}
+
+	return table.cells[cell&^bigCellFlag]
+}
+
+func (table *bigCellTable) apply(operation func(z, x, y *big.Int) *big.Int, x, y memoryCell) memoryCell {
+	return table.box(operation(new(big.Int), table.unbox(x), table.unbox(y)))
+}
+
+// there is no maximum value in big-integer mode, so only DivisionByZeroZero avoids an error
+func (table *bigCellTable) divide(dividend, divisor memoryCell, mode DivisionByZeroMode) (quotient memoryCell, err error) {
+	y := table.unbox(divisor)
+
+	if y.Sign() == 0 {
+		if mode != DivisionByZeroZero {
+			err = fmt.Errorf("%w: %s / 0", ErrDivisionByZero, table.unbox(dividend))
+		}
+
+		return
+	}
+
+	quotient = table.box(new(big.Int).Quo(table.unbox(dividend), y))
+
+	return
+}
+
+func (table *bigCellTable) modulo(dividend, divisor memoryCell, mode DivisionByZeroMode) (remainder memoryCell, err error) {
+	y := table.unbox(divisor)
+
+	if y.Sign() == 0 {
+		if mode != DivisionByZeroZero {
+			err = fmt.Errorf("%w: %s %% 0", ErrDivisionByZero, table.unbox(dividend))
+			return
+		}
+
+		remainder = dividend
+
+		return
+	}
+
+	remainder = table.box(new(big.Int).Rem(table.unbox(dividend), y))
+
+	return
+}
+
+func (table *bigCellTable) compare(x, y memoryCell) int {
+	if x&bigCellFlag == 0 && y&bigCellFlag == 0 {
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+
+		return 0
+	}
+
+	return table.unbox(x).Cmp(table.unbox(y))
+}
+
+// values that do not fit into 64 bits, including negative values, are given as the maximum value
+func (table *bigCellTable) uint64Cell(cell memoryCell) memoryCell {
+	n := table.unbox(cell)
+
+	if n.Sign() < 0 || !n.IsUint64() {
+		return ^memoryCell(0)
+	}
+
+	return memoryCellFromIntegerConstraint(n.Uint64())
+}
+
+func (table *bigCellTable) rune(cell memoryCell) rune {
+	n := table.unbox(cell)
+
+	if !n.IsInt64() || n.Int64() < 0 || n.Int64() > utf8.MaxRune {
+		return utf8.RuneError
+	}
+
+	return rune(n.Int64())
+}
+
+// only the cells that can be reached from the roots are kept, and the roots are changed to refer to their new positions
+func (table *bigCellTable) collect(roots ...memoryCellCollection) {
+	positions := make(map[memoryCell]memoryCell)
+	cells := make([]*big.Int, 0, len(table.cells)/2)
+
+	for _, root := range roots {
+		for i, cell := range root {
+			if cell&bigCellFlag == 0 {
+				continue
+			}
+
+			position, found := positions[cell]
+			if !found {
+				cells = append(cells, table.cells[cell&^bigCellFlag])
+				position = bigCellFlag | memoryCellFromIntegerConstraint(len(cells)-1)
+				positions[cell] = position
+			}
+
+			root[i] = position
+		}
+	}
+
+	table.cells = cells
+
+	table.collectAt = 2 * len(cells)
+	if table.collectAt < bigCellTableCollectMinLen {
+		table.collectAt = bigCellTableCollectMinLen
+	}
+}
+
+func (tree *tree) bigMode() bool {
+	return tree != nil && tree.bigCells != nil
+}
+
+// this is only called between commands, when every value is either on a stack, held by a context section or the current value;
+// included files never collect, since the values held by the files that include them cannot be seen
+func (tree *tree) collectBigCells(input memoryCell) (output memoryCell) {
+	if !tree.bigCollecting || len(tree.bigCells.cells) < tree.bigCells.collectAt {
+		output = input
+		return
+	}
+
+	current := memoryCellCollection{input}
+
+	tree.bigCells.collect(
+		tree.interpretCodeOptions.saveStacks[0],
+		tree.interpretCodeOptions.saveStacks[1],
+		tree.bigRoots,
+		current,
+	)
+
+	output = current[0]
+
+	return
+}
+
+func (tree *tree) pushBigRoot(value memoryCell) (index int) {
+	if tree.bigMode() {
+		index = len(tree.bigRoots)
+		tree.bigRoots = append(tree.bigRoots, value)
+	}
+
+	return
+}
+
+func (tree *tree) popBigRoot(index int, value memoryCell) (output memoryCell) {
+	output = value
+
+	if tree.bigMode() {
+		output = tree.bigRoots[index]
+		tree.bigRoots = tree.bigRoots[:index]
+	}
+
+	return
+}
+
+func (tree *tree) bigSaveStackFileName(value memoryCell) string {
+	return tree.bigCells.unbox(value).String() + FileExtensionForSaveStack
+}
+
+// every command is listed, so that one which reads the number in a cell cannot be left to the usual path by mistake
+func (node *terminalTreeNode) bigValue(input memoryCell) (output memoryCell, handled bool, err error) {
+	output = input
+	handled = true
+
+	table := node.tree.bigCells
+
+	switch node.lexeme {
+	case setLiteralLexeme:
+		{
+			var value memoryCell
+			value, err = parseNumericLiteral(node.data)
+			if err != nil {
+				return
+			}
+
+			output = table.box(new(big.Int).SetUint64(value.Uint64()))
+		}
+	case setRandomMaxLexeme:
+		{
+			var n *big.Int
+			n, err = rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 64))
+			if err != nil {
+				return
+			}
+
+			output = table.box(n)
+		}
+	case hashStackEightByteLexeme:
+		{
+			var saveStack memoryCellCollection
+			saveStack, err = node.tree.saveStack()
+			if err != nil {
+				return
+			}
+
+			output = table.box(new(big.Int).SetUint64(hash.Uint64(node.tree.bigStackContent(saveStack))))
+		}
+	case hashStackOneByteLexeme:
+		{
+			var saveStack memoryCellCollection
+			saveStack, err = node.tree.saveStack()
+			if err != nil {
+				return
+			}
+
+			output = memoryCellFromIntegerConstraint(hash.Uint8(node.tree.bigStackContent(saveStack)))
+		}
+	case bitwiseAndStackPairLexeme,
+		bitwiseOrStackPairLexeme,
+		bitwiseXorStackPairLexeme:
+		{
+			var value1, value2 memoryCell
+			value1, value2, err = node.tree.popStackPair()
+			if err != nil {
+				return
+			}
+
+			switch node.lexeme {
+			case bitwiseAndStackPairLexeme:
+				output = table.apply((*big.Int).And, value1, value2)
+			case bitwiseOrStackPairLexeme:
+				output = table.apply((*big.Int).Or, value1, value2)
+			case bitwiseXorStackPairLexeme:
+				output = table.apply((*big.Int).Xor, value1, value2)
+			}
+		}
+	case bitwiseAndStackWholeLexeme,
+		bitwiseOrStackWholeLexeme,
+		bitwiseXorStackWholeLexeme:
+		{
+			var values memoryCellCollection
+			values, err = node.tree.popStackWhole()
+			if err != nil {
+				return
+			}
+
+			result := new(big.Int).Set(table.unbox(values[len(values)-1]))
+
+			for i := len(values) - 2; i >= 0; i-- {
+				switch node.lexeme {
+				case bitwiseAndStackWholeLexeme:
+					result.And(result, table.unbox(values[i]))
+				case bitwiseOrStackWholeLexeme:
+					result.Or(result, table.unbox(values[i]))
+				case bitwiseXorStackWholeLexeme:
+					result.Xor(result, table.unbox(values[i]))
+				}
+			}
+
+			output = table.box(result)
+		}
+	case bitwiseNotLexeme:
+		output = table.box(new(big.Int).Not(table.unbox(input)))
+	case bitCountLexeme:
+		{
+			var count int
+
+			for _, word := range table.unbox(input).Bits() {
+				count += bits.OnesCount(uint(word))
+			}
+
+			output = memoryCellFromIntegerConstraint(count)
+		}
+	case shiftLeftOneLexeme:
+		output = table.box(new(big.Int).Lsh(table.unbox(input), 1))
+	case shiftLeftEightLexeme:
+		output = table.box(new(big.Int).Lsh(table.unbox(input), 8))
+	case shiftRightOneLexeme:
+		output = table.box(new(big.Int).Rsh(table.unbox(input), 1))
+	case shiftRightEightLexeme:
+		output = table.box(new(big.Int).Rsh(table.unbox(input), 8))
+	case shiftLeftStackLexeme,
+		shiftRightStackLexeme:
+		{
+			var shift memoryCell
+			shift, err = node.tree.popStackLast()
+			if err != nil {
+				return
+			}
+
+			if shift > bigCellShiftMaxLen {
+				err = &PositionError{Position: node.span.start, Err: fmt.Errorf("%w: %s", ErrShiftOutOfRange, table.unbox(shift))}
+				return
+			}
+
+			if node.lexeme == shiftLeftStackLexeme {
+				output = table.box(new(big.Int).Lsh(table.unbox(input), uint(shift)))
+			} else {
+				output = table.box(new(big.Int).Rsh(table.unbox(input), uint(shift)))
+			}
+		}
+	case equalStackPairLexeme,
+		notEqualStackPairLexeme,
+		lessStackPairLexeme,
+		greaterStackPairLexeme,
+		lessOrEqualStackPairLexeme,
+		greaterOrEqualStackPairLexeme:
+		{
+			var saveStack memoryCellCollection
+			saveStack, err = node.tree.saveStack()
+			if err != nil {
+				return
+			}
+
+			if len(saveStack) < 2 {
+				err = ErrTreeSaveStackEmpty
+				return
+			}
+
+			comparison := table.compare(saveStack[len(saveStack)-1], saveStack[len(saveStack)-2])
+
+			var result bool
+
+			switch node.lexeme {
+			case equalStackPairLexeme:
+				result = comparison == 0
+			case notEqualStackPairLexeme:
+				result = comparison != 0
+			case lessStackPairLexeme:
+				result = comparison < 0
+			case greaterStackPairLexeme:
+				result = comparison > 0
+			case lessOrEqualStackPairLexeme:
+				result = comparison <= 0
+			case greaterOrEqualStackPairLexeme:
+				result = comparison >= 0
+			}
+
+			if result {
+				output = 1
+			} else {
+				output = 0
+			}
+		}
+	case printCharacterLexeme:
+		_, err = fmt.Fprintf(node.tree.interpretCodeOptions.Output, "%c", table.rune(input))
+	case printNumberLexeme:
+		_, err = fmt.Fprint(node.tree.interpretCodeOptions.Output, table.unbox(input))
+	case inputNumberLexeme:
+		{
+			n := new(big.Int)
+
+			if _, err = fmt.Fscan(node.tree.interpretCodeOptions.Input, n); err != nil {
+				return
+			}
+
+			output = table.box(n)
+		}
+	case sortStackAscendingLexeme,
+		sortStackDescendingLexeme:
+		{
+			var saveStack memoryCellCollection
+			saveStack, err = node.tree.saveStack()
+			if err != nil {
+				return
+			}
+
+			sort.SliceStable(saveStack, func(i, j int) bool {
+				if node.lexeme == sortStackDescendingLexeme {
+					return table.compare(saveStack[i], saveStack[j]) > 0
+				}
+
+				return table.compare(saveStack[i], saveStack[j]) < 0
+			})
+		}
+	case writeStackToFileLexeme:
+		{
+			var saveStack memoryCellCollection
+			saveStack, err = node.tree.saveStack()
+			if err != nil {
+				return
+			}
+
+			var contentBuilder bytes.Buffer
+
+			for _, value := range saveStack {
+				contentBuilder.WriteString(table.unbox(value).String())
+				contentBuilder.WriteByte('\n')
+			}
+
+			err = os.WriteFile(node.tree.bigSaveStackFileName(input), contentBuilder.Bytes(), os.ModePerm)
+		}
+	case readStackFromFileLexeme:
+		{
+			var content []byte
+			content, err = os.ReadFile(node.tree.bigSaveStackFileName(input))
+			if err != nil {
+				return
+			}
+
+			var saveStackPtr *memoryCellCollection
+			saveStackPtr, err = node.tree.saveStackPtr()
+			if err != nil {
+				return
+			}
+			saveStack := (*saveStackPtr)[:0]
+
+			scanner := bufio.NewScanner(bytes.NewReader(content))
+			scanner.Buffer(nil, len(content)+1)
+
+			for scanner.Scan() {
+				if len(saveStack) >= interpretCodeOptionsSaveStackMaxLen {
+					err = ErrTreeSaveStackFull
+					return
+				}
+
+				n, ok := new(big.Int).SetString(scanner.Text(), 10)
+				if !ok {
+					err = fmt.Errorf("%w: %s", ErrNumericLiteralInvalid, scanner.Text())
+					return
+				}
+
+				saveStack = append(saveStack, table.box(n))
+			}
+
+			*saveStackPtr = saveStack
+		}
+	case deleteFileLexeme:
+		err = os.Remove(node.tree.bigSaveStackFileName(input))
+	case pickStackLexeme,
+		rollStackLexeme,
+		giveStackManyLexeme,
+		iotaFromZeroLexeme,
+		iotaFromOneLexeme:
+		{
+			// the current value is used as a depth or a count, so it must be small enough to be held without the table
+			if input&bigCellFlag != 0 {
+				err = &PositionError{Position: node.span.start, Err: fmt.Errorf("%w: %s", ErrBigIntegerOutOfRange, table.unbox(input))}
+				return
+			}
+
+			handled = false
+		}
+	case addOneLexeme,
+		addEightLexeme,
+		addStackPairLexeme,
+		addStackWholeLexeme,
+		subtractOneLexeme,
+		subtractEightLexeme,
+		subtractStackPairLexeme,
+		subtractStackWholeLexeme,
+		multiplyTwoLexeme,
+		multiplyEightLexeme,
+		multiplyStackPairLexeme,
+		multiplyStackWholeLexeme,
+		divideTwoLexeme,
+		divideEightLexeme,
+		divideStackPairLexeme,
+		divideStackWholeLexeme,
+		moduloTwoLexeme,
+		moduloEightLexeme,
+		moduloStackPairLexeme,
+		moduloStackWholeLexeme,
+		squareLexeme,
+		cubeLexeme:
+		handled = false // the arithmetic of the tree works on big integers
+	case logicalAndStackPairLexeme,
+		logicalAndStackWholeLexeme,
+		logicalOrStackPairLexeme,
+		logicalOrStackWholeLexeme,
+		logicalXorStackPairLexeme,
+		logicalXorStackWholeLexeme,
+		invertLexeme:
+		handled = false // zero is never kept in the table, so a cell is non-zero exactly when its value is
+	case setZeroLexeme,
+		setOneByteLexeme,
+		setEightByteLexeme,
+		setOneKibibyteLexeme,
+		setEightKibibyteLexeme,
+		setOneMebibyteLexeme,
+		setEightMebibyteLexeme,
+		setOneGibibyteLexeme,
+		setEightGibibyteLexeme,
+		setRandomByteLexeme,
+		setSecondTimestampLexeme,
+		setNanosecondTimestampLexeme,
+		pushStringLexeme,
+		countStackLexeme,
+		inputCharacterLexeme:
+		handled = false // these only produce values that are small enough to be held without the table
+	case useStackIndexZeroLexeme,
+		useStackIndexOneLexeme,
+		useStackIndexSwappedLexeme,
+		pushStackLexeme,
+		popStackLastLexeme,
+		popStackRandomLexeme,
+		peekStackTopLexeme,
+		shuffleStackLexeme,
+		swapStackTopLexeme,
+		reverseStackLexeme,
+		duplicateStackTopLexeme,
+		overStackLexeme,
+		dropStackTopLexeme,
+		rotateStackThreeLexeme,
+		giveStackTopLexeme,
+		giveStackWholeLexeme,
+		clearStackLexeme,
+		resetStateLexeme,
+		callProcedureLexeme,
+		setOverflowModeLexeme,
+		breakLoopLexeme,
+		continueLoopLexeme,
+		filePathLexeme,
+		changeDirLexeme:
+		handled = false // these only move cells around, or do not use their values
+	default:
+		err = fmt.Errorf("%w in big-integer mode: %s", ErrLexemeUnrecognized, node.lexeme.name())
+	}
+
+	return
+}
+
+// the same runes are hashed as in the usual mode for values that fit into 64 bits, and the decimal digits of any others
+func (tree *tree) bigStackContent(saveStack memoryCellCollection) []byte {
+	var contentBuilder bytes.Buffer
+
+	for _, value := range saveStack {
+		n := tree.bigCells.unbox(value)
+
+		if n.Sign() >= 0 && n.IsUint64() {
+			contentBuilder.WriteRune(rune(n.Uint64()))
+		} else {
+			contentBuilder.WriteString(n.String())
+		}
+	}
+
+	return contentBuilder.Bytes()
+}
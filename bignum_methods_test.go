@@ -0,0 +1,234 @@
+package dorklang
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"math/big"
+	"os"
+	"strings"
+	"testing"
+)
+
+const (
+	bigTestTwoToSixtyThree = "63 : 1 %h"
+	bigTestTwoToSixtyFour  = "64 : 1 %h"
+)
+
+func interpretBigCode(t *testing.T, source string, bigIntegers bool) (printed string, output uint64, err error) {
+	var outputBuffer bytes.Buffer
+
+	workingDir := t.TempDir()
+
+	// the working directory is not changed back when a program fails
+	initialDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		os.Chdir(initialDir)
+	})
+
+	options := InterpretCodeDefaultOptions.Clone()
+	options.WorkingDir = workingDir
+	options.BigIntegers = bigIntegers
+	options.Input = strings.NewReader("")
+	options.Output = &outputBuffer
+
+	output, err = InterpretCode([]byte(source), options)
+	printed = outputBuffer.String()
+
+	return
+}
+
+func TestBigIntegersPrint(t *testing.T) {
+	testCases := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{"two to the sixty-three", bigTestTwoToSixtyThree + " !!", "9223372036854775808"},
+		{"two to the sixty-four", bigTestTwoToSixtyFour + " !!", "18446744073709551616"},
+		{"negative", "0 - !!", "-1"},
+		{"factorial", "31 ii %** !!", "265252859812191058636308480000000"},
+		{"not", bigTestTwoToSixtyFour + " n !!", "-18446744073709551617"},
+		{"bit count", bigTestTwoToSixtyFour + " - b !!", "64"},
+		{"shift right", bigTestTwoToSixtyFour + " l !!", "9223372036854775808"},
+		{"division", bigTestTwoToSixtyFour + " // !!", "2305843009213693952"},
+		{"modulo section", bigTestTwoToSixtyFour + " + %[ 3 %] !!", "2"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			printed, _, err := interpretBigCode(t, testCase.source, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if printed != testCase.expected {
+				t.Errorf("expected %s, got %s", testCase.expected, printed)
+			}
+		})
+	}
+}
+
+func TestBigIntegersCompare(t *testing.T) {
+	testCases := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{"equal above sixty-three bits", bigTestTwoToSixtyThree + " : " + bigTestTwoToSixtyThree + " : %= !!", "1"},
+		{"equal above sixty-four bits", bigTestTwoToSixtyFour + " : " + bigTestTwoToSixtyFour + " : %= !!", "1"},
+		{"not equal", bigTestTwoToSixtyFour + " : " + bigTestTwoToSixtyFour + " + : %<> !!", "1"},
+		{"less", bigTestTwoToSixtyFour + " : " + bigTestTwoToSixtyThree + " : %< !!", "1"},
+		{"greater", bigTestTwoToSixtyFour + " : " + bigTestTwoToSixtyThree + " : %> !!", "0"},
+		{"negative less", "7 : 0 - : %< !!", "1"},
+		{"logical and", bigTestTwoToSixtyFour + " : 0 : %& !!", "0"},
+		{"logical or", bigTestTwoToSixtyFour + " : 0 : %v !!", "1"},
+		{"logical xor", bigTestTwoToSixtyFour + " : " + bigTestTwoToSixtyThree + " : %^ !!", "0"},
+		{"logical and whole", bigTestTwoToSixtyFour + " : 0 - : %&& !!", "1"},
+		{"invert", bigTestTwoToSixtyFour + " \\ !!", "0"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			printed, _, err := interpretBigCode(t, testCase.source, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if printed != testCase.expected {
+				t.Errorf("expected %s, got %s", testCase.expected, printed)
+			}
+		})
+	}
+}
+
+func TestBigIntegersStack(t *testing.T) {
+	testCases := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{"swap", bigTestTwoToSixtyFour + " : 7 : x ; !! ; !!", "184467440737095516167"},
+		{"duplicate", bigTestTwoToSixtyFour + " : d ; !! ; !!", "1844674407370955161618446744073709551616"},
+		{"sort", bigTestTwoToSixtyFour + " : 0 - : " + bigTestTwoToSixtyThree + " : 5 : s ; !! ; !! ; !! ; !!", "1844674407370955161692233720368547758085-1"},
+		{"sort descending", bigTestTwoToSixtyThree + " : 5 : " + bigTestTwoToSixtyFour + " : ss ; !! ; !! ; !!", "5922337203685477580818446744073709551616"},
+		{"pick", bigTestTwoToSixtyFour + " : 3 : 1 %d ; !!", "18446744073709551616"},
+		{"sum", bigTestTwoToSixtyFour + " : " + bigTestTwoToSixtyFour + " : %++ !!", "36893488147419103232"},
+		{"file", bigTestTwoToSixtyFour + " : 0 - : 7 . ; ; 7 , ; !! ; !! 7 |", "-118446744073709551616"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			printed, _, err := interpretBigCode(t, testCase.source, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if printed != testCase.expected {
+				t.Errorf("expected %s, got %s", testCase.expected, printed)
+			}
+		})
+	}
+}
+
+func TestBigIntegersCountOutOfRange(t *testing.T) {
+	testCases := []struct {
+		name   string
+		source string
+	}{
+		{"pick above sixty-three bits", "1 : " + bigTestTwoToSixtyThree + " %d"},
+		{"roll above sixty-four bits", "1 : " + bigTestTwoToSixtyFour + " %t"},
+		{"give", "1 : " + bigTestTwoToSixtyFour + " %g"},
+		{"iota", bigTestTwoToSixtyThree + " i"},
+		{"negative iota", "0 - ii"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, _, err := interpretBigCode(t, testCase.source, true)
+			if !errors.Is(err, ErrBigIntegerOutOfRange) {
+				t.Errorf("expected %v, got %v", ErrBigIntegerOutOfRange, err)
+			}
+		})
+	}
+}
+
+func TestBigIntegersHash(t *testing.T) {
+	for _, source := range []string{"0 : ##", "0 : #", "7 : 1000 : ##", bigTestTwoToSixtyThree + " : ##"} {
+		usualPrinted, _, err := interpretBigCode(t, source+" !!", false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		bigPrinted, _, err := interpretBigCode(t, source+" !!", true)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if usualPrinted != bigPrinted {
+			t.Errorf("%s: expected the same hash in both modes, got %s and %s", source, usualPrinted, bigPrinted)
+		}
+	}
+
+	for _, command := range []string{"#", "##"} {
+		zeroPrinted, _, err := interpretBigCode(t, "0 : "+command+" !!", true)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		bigPrinted, _, err := interpretBigCode(t, bigTestTwoToSixtyFour+" : "+command+" !!", true)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if zeroPrinted == bigPrinted {
+			t.Errorf("%s: the hash of two to the sixty-four must not be the hash of zero", command)
+		}
+	}
+}
+
+func TestBigIntegersCollect(t *testing.T) {
+	// each step leaves a new value on the stack, so the table is collected many times while the values are still in use
+	source := "$ 0 : 1 : $$ 20000 : < $$ ; - : $ x o %+ : $$ ; : > $ ; !! ; !!"
+
+	printed, _, err := interpretBigCode(t, source, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, b := big.NewInt(0), big.NewInt(1)
+	for i := 0; i < 20000; i++ {
+		a.Add(a, b)
+		a, b = b, a
+	}
+
+	if expected := b.String() + a.String(); printed != expected {
+		t.Errorf("unexpected Fibonacci numbers:\n%s", printed)
+	}
+}
+
+func TestBigIntegersOutput(t *testing.T) {
+	testCases := []struct {
+		source   string
+		expected uint64
+	}{
+		{bigTestTwoToSixtyThree, 1 << 63},
+		{bigTestTwoToSixtyFour + " -", math.MaxUint64},
+		{bigTestTwoToSixtyFour, math.MaxUint64},
+		{"0 -", math.MaxUint64},
+	}
+
+	for _, testCase := range testCases {
+		_, output, err := interpretBigCode(t, testCase.source, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if output != testCase.expected {
+			t.Errorf("%s: expected %d, got %d", testCase.source, testCase.expected, output)
+		}
+	}
+}
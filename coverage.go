@@ -0,0 +1,48 @@
+package dorklang
+
+const (
+	coverageInputFilePath   = "<input>"
+	coverageHTMLShadeLevels = 10
+)
+
+type Coverage struct {
+	entries map[coverageKey]*coverageEntry
+	sources map[string][]byte
+}
+
+type coverageKey struct {
+	filePath string
+	offset   int
+	lexeme   lexeme
+}
+
+type coverageEntry struct {
+	lexeme  lexeme
+	span    sourceSpan
+	endSpan sourceSpan
+	count   uint64
+}
+
+type coverageFile struct {
+	filePath string
+	source   []byte
+	entries  []*coverageEntry
+}
+
+type coverageReport struct {
+	Files []coverageReportFile `json:"files"`
+}
+
+type coverageReportFile struct {
+	FilePath string               `json:"filePath"`
+	Commands int                  `json:"commands"`
+	Executed int                  `json:"executed"`
+	Nodes    []coverageReportNode `json:"nodes"`
+}
+
+type coverageReportNode struct {
+	Lexeme string   `json:"lexeme"`
+	Start  Position `json:"start"`
+	End    Position `json:"end"`
+	Count  uint64   `json:"count"`
+}
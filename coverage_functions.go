@@ -0,0 +1,37 @@
+package dorklang
+
+import (
+	"fmt"
+	"math"
+)
+
+func NewCoverage() *Coverage {
+	return &Coverage{
+		entries: make(map[coverageKey]*coverageEntry),
+		sources: make(map[string][]byte),
+	}
+}
+
+func coverageShadeLevel(count, maxCount int64) int {
+	if count == 0 || maxCount == 0 {
+		return 0
+	}
+
+	level := int(math.Ceil(coverageHTMLShadeLevels * math.Log1p(float64(count)) / math.Log1p(float64(maxCount))))
+
+	if level < 1 {
+		level = 1
+	} else if level > coverageHTMLShadeLevels {
+		level = coverageHTMLShadeLevels
+	}
+
+	return level
+}
+
+func coveragePercentage(executed, commands int) string {
+	if commands == 0 {
+		return "100.0%"
+	}
+
+	return fmt.Sprintf("%.1f%%", float64(executed)/float64(commands)*100)
+}
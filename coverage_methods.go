@@ -0,0 +1,301 @@
+package dorklang
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+func (coverage *Coverage) addSource(filePath string, source []byte) {
+	if _, found := coverage.sources[filePath]; found {
+		return
+	}
+
+	sourceCopy := make([]byte, len(source))
+	copy(sourceCopy, source)
+
+	coverage.sources[filePath] = sourceCopy
+}
+
+func (coverage *Coverage) addTree(tr *tree) {
+	for _, node := range tr.rootNode.childNodes {
+		coverage.addNode(node)
+	}
+}
+
+func (coverage *Coverage) addNode(node treeNode) {
+	switch node.getLexeme() {
+	case changeDirLexeme,
+		startCommentSectionLexeme:
+		return
+	}
+
+	span := node.getSpan()
+	if !span.start.IsValid() {
+		return
+	}
+
+	key := coverageKey{
+		filePath: span.start.FilePath,
+		offset:   span.start.Offset,
+		lexeme:   node.getLexeme(),
+	}
+
+	entry, found := coverage.entries[key]
+	if !found {
+		entry = &coverageEntry{
+			lexeme: node.getLexeme(),
+			span:   span,
+		}
+
+		coverage.entries[key] = entry
+	}
+
+	switch node := node.(type) {
+	case *parentTreeNode:
+		node.coverageEntry = entry
+		entry.endSpan = node.endSpan
+
+		for _, node2 := range node.childNodes {
+			coverage.addNode(node2)
+		}
+	case *terminalTreeNode:
+		node.coverageEntry = entry
+	}
+}
+
+func (coverage *Coverage) files() (files []*coverageFile, err error) {
+	filesByPath := make(map[string]*coverageFile)
+
+	for key, entry := range coverage.entries {
+		file, found := filesByPath[key.filePath]
+		if !found {
+			file = &coverageFile{
+				filePath: key.filePath,
+			}
+
+			filesByPath[key.filePath] = file
+			files = append(files, file)
+		}
+
+		file.entries = append(file.entries, entry)
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].filePath < files[j].filePath
+	})
+
+	for _, file := range files {
+		sort.Slice(file.entries, func(i, j int) bool {
+			if file.entries[i].span.start.Offset != file.entries[j].span.start.Offset {
+				return file.entries[i].span.start.Offset < file.entries[j].span.start.Offset
+			}
+
+			return file.entries[i].lexeme < file.entries[j].lexeme
+		})
+
+		source, found := coverage.sources[file.filePath]
+		if !found {
+			source, err = os.ReadFile(file.filePath)
+			if err != nil {
+				return
+			}
+		}
+
+		file.source = source
+	}
+
+	return
+}
+
+func (file *coverageFile) displayPath() string {
+	if file.filePath == "" {
+		return coverageInputFilePath
+	}
+
+	return file.filePath
+}
+
+func (file *coverageFile) executedCount() (executed int) {
+	for _, entry := range file.entries {
+		if entry.count > 0 {
+			executed++
+		}
+	}
+
+	return
+}
+
+func (coverage *Coverage) WriteText(output io.Writer) (err error) {
+	files, err := coverage.files()
+	if err != nil {
+		return
+	}
+
+	writer := tabwriter.NewWriter(output, 0, 8, 2, ' ', 0)
+
+	var commandsTotal, executedTotal int
+
+	for _, file := range files {
+		commands := len(file.entries)
+		executed := file.executedCount()
+
+		commandsTotal += commands
+		executedTotal += executed
+
+		if _, err = fmt.Fprintf(writer, "%s\t%d/%d\t%s\n", file.displayPath(), executed, commands, coveragePercentage(executed, commands)); err != nil {
+			return
+		}
+	}
+
+	if _, err = fmt.Fprintf(writer, "total\t%d/%d\t%s\n", executedTotal, commandsTotal, coveragePercentage(executedTotal, commandsTotal)); err != nil {
+		return
+	}
+
+	err = writer.Flush()
+
+	return
+}
+
+func (coverage *Coverage) WriteJSON(output io.Writer) (err error) {
+	files, err := coverage.files()
+	if err != nil {
+		return
+	}
+
+	report := coverageReport{
+		Files: make([]coverageReportFile, 0, len(files)),
+	}
+
+	for _, file := range files {
+		reportFile := coverageReportFile{
+			FilePath: file.displayPath(),
+			Commands: len(file.entries),
+			Executed: file.executedCount(),
+			Nodes:    make([]coverageReportNode, 0, len(file.entries)),
+		}
+
+		for _, entry := range file.entries {
+			start := entry.span.start
+			start.FilePath = ""
+
+			end := entry.span.end
+			end.FilePath = ""
+
+			reportFile.Nodes = append(reportFile.Nodes, coverageReportNode{
+				Lexeme: entry.lexeme.name(),
+				Start:  start,
+				End:    end,
+				Count:  entry.count,
+			})
+		}
+
+		report.Files = append(report.Files, reportFile)
+	}
+
+	encoder := json.NewEncoder(output)
+	encoder.SetIndent("", "\t")
+
+	err = encoder.Encode(report)
+
+	return
+}
+
+func (coverage *Coverage) WriteHTML(output io.Writer) (err error) {
+	files, err := coverage.files()
+	if err != nil {
+		return
+	}
+
+	var builder strings.Builder
+
+	builder.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>dorklang coverage</title>\n<style>\n")
+	builder.WriteString("body { font-family: sans-serif; }\n")
+	builder.WriteString("pre { font-family: monospace; line-height: 1.4; }\n")
+	builder.WriteString(".c0 { background-color: rgba(220, 50, 47, 0.45); }\n")
+
+	for i := 1; i <= coverageHTMLShadeLevels; i++ {
+		fmt.Fprintf(&builder, ".c%d { background-color: rgba(40, 160, 60, %.2f); }\n", i, float64(i)/coverageHTMLShadeLevels*0.8)
+	}
+
+	builder.WriteString("</style>\n</head>\n<body>\n")
+
+	for _, file := range files {
+		commands := len(file.entries)
+		executed := file.executedCount()
+
+		fmt.Fprintf(
+			&builder,
+			"<h2>%s</h2>\n<p>%d/%d commands executed (%s)</p>\n<pre>",
+			html.EscapeString(file.displayPath()),
+			executed,
+			commands,
+			coveragePercentage(executed, commands),
+		)
+
+		counts := file.characterCounts()
+
+		var maxCount int64
+		for _, count := range counts {
+			if count > maxCount {
+				maxCount = count
+			}
+		}
+
+		for i := 0; i < len(file.source); {
+			j := i + 1
+			for j < len(file.source) && counts[j] == counts[i] {
+				j++
+			}
+
+			text := html.EscapeString(string(file.source[i:j]))
+
+			if count := counts[i]; count < 0 {
+				builder.WriteString(text)
+			} else {
+				fmt.Fprintf(
+					&builder,
+					"<span class=\"c%d\" title=\"%d\">%s</span>",
+					coverageShadeLevel(count, maxCount),
+					count,
+					text,
+				)
+			}
+
+			i = j
+		}
+
+		builder.WriteString("</pre>\n")
+	}
+
+	builder.WriteString("</body>\n</html>\n")
+
+	_, err = io.WriteString(output, builder.String())
+
+	return
+}
+
+func (file *coverageFile) characterCounts() (counts []int64) {
+	counts = make([]int64, len(file.source))
+
+	for i := range counts {
+		counts[i] = -1
+	}
+
+	for _, entry := range file.entries {
+		for _, span := range []sourceSpan{entry.span, entry.endSpan} {
+			for i := span.start.Offset; span.contains(i) && i < len(counts); i++ {
+				if count := int64(entry.count); count > counts[i] {
+					counts[i] = count
+				}
+			}
+		}
+	}
+
+	return
+}
@@ -0,0 +1,35 @@
+package dorklang
+
+type DependencyFormat int
+
+const (
+	DependencyFormatTree DependencyFormat = iota
+	DependencyFormatJSON
+	DependencyFormatDOT
+)
+
+type DependencyGraph struct {
+	FilePath     string        `json:"filePath"`
+	Dependencies []*Dependency `json:"dependencies"`
+	Missing      []string      `json:"missing"`
+	Repeated     []string      `json:"repeated"`
+	rootDir      string
+}
+
+type Dependency struct {
+	Path         string        `json:"path"`
+	FilePath     string        `json:"filePath"`
+	Start        Position      `json:"start"`
+	Code         bool          `json:"code"`
+	Missing      bool          `json:"missing,omitempty"`
+	Repeated     bool          `json:"repeated,omitempty"`
+	Cycle        bool          `json:"cycle,omitempty"`
+	Error        string        `json:"error,omitempty"`
+	Dependencies []*Dependency `json:"dependencies,omitempty"`
+}
+
+type dependencyResolver struct {
+	counts   map[string]int
+	expanded map[string]bool
+	missing  map[string]bool
+}
@@ -0,0 +1,95 @@
+package dorklang
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+func ParseDependencyFormat(s string) (format DependencyFormat, err error) {
+	switch strings.ToLower(s) {
+	case "tree":
+		format = DependencyFormatTree
+	case "json":
+		format = DependencyFormatJSON
+	case "dot", "graphviz":
+		format = DependencyFormatDOT
+	default:
+		err = ErrDependencyFormatUnrecognized
+	}
+
+	return
+}
+
+func ResolveDependencies(input []byte, options InterpretCodeOptions) (graph *DependencyGraph, err error) {
+	rootDir := options.WorkingDir
+	if rootDir == "" {
+		rootDir, err = os.Getwd()
+		if err != nil {
+			return
+		}
+	}
+
+	rootDir, err = filepath.Abs(rootDir)
+	if err != nil {
+		return
+	}
+
+	filePath := options.FilePath
+	if filePath != "" {
+		filePath, err = filepath.Abs(filePath)
+		if err != nil {
+			return
+		}
+	}
+
+	resolver := &dependencyResolver{
+		counts:   make(map[string]int),
+		expanded: make(map[string]bool),
+		missing:  make(map[string]bool),
+	}
+
+	var chain []string
+	if filePath != "" {
+		chain = append(chain, filePath)
+	}
+
+	dependencies, err := resolver.resolve(input, filePath, rootDir, chain)
+	if err != nil {
+		return
+	}
+
+	graph = &DependencyGraph{
+		FilePath:     filePath,
+		Dependencies: dependencies,
+		Missing:      make([]string, 0, len(resolver.missing)),
+		Repeated:     make([]string, 0),
+		rootDir:      rootDir,
+	}
+
+	for dependencyFilePath := range resolver.missing {
+		graph.Missing = append(graph.Missing, dependencyFilePath)
+	}
+
+	for dependencyFilePath, count := range resolver.counts {
+		if count > 1 {
+			graph.Repeated = append(graph.Repeated, dependencyFilePath)
+		}
+	}
+
+	sort.Strings(graph.Missing)
+	sort.Strings(graph.Repeated)
+
+	markRepeatedDependencies(graph.Dependencies, resolver.counts)
+
+	return
+}
+
+func markRepeatedDependencies(dependencies []*Dependency, counts map[string]int) {
+	for _, dependency := range dependencies {
+		dependency.Repeated = counts[dependency.FilePath] > 1
+
+		markRepeatedDependencies(dependency.Dependencies, counts)
+	}
+}
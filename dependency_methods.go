@@ -0,0 +1,265 @@
+package dorklang
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+func (format DependencyFormat) String() string {
+	switch format {
+	case DependencyFormatTree:
+		return "tree"
+	case DependencyFormatJSON:
+		return "json"
+	case DependencyFormatDOT:
+		return "dot"
+	}
+
+	return "unknown"
+}
+
+func (resolver *dependencyResolver) resolve(source []byte, filePath string, dir string, chain []string) (dependencies []*Dependency, err error) {
+	tokens, err := produceTokens(source, filePath)
+	if err != nil {
+		return
+	}
+
+	for _, t := range tokens {
+		if t.lex != filePathLexeme || len(t.data) == 0 {
+			continue
+		}
+
+		dependency := &Dependency{
+			Path:     string(t.data),
+			FilePath: string(t.data),
+			Start:    t.span.start,
+		}
+
+		if !filepath.IsAbs(dependency.FilePath) {
+			dependency.FilePath = filepath.Join(dir, dependency.FilePath)
+		}
+
+		dependency.Code = filepath.Ext(dependency.FilePath) == FileExtensionForCode
+
+		dependencies = append(dependencies, dependency)
+
+		resolver.counts[dependency.FilePath]++
+
+		if _, err2 := os.Stat(dependency.FilePath); err2 != nil {
+			dependency.Missing = true
+			resolver.missing[dependency.FilePath] = true
+			continue
+		}
+
+		if !dependency.Code {
+			continue
+		}
+
+		for _, chainFilePath := range chain {
+			if chainFilePath == dependency.FilePath {
+				dependency.Cycle = true
+				break
+			}
+		}
+
+		if dependency.Cycle || resolver.expanded[dependency.FilePath] {
+			continue
+		}
+
+		resolver.expanded[dependency.FilePath] = true
+
+		content, err2 := os.ReadFile(dependency.FilePath)
+		if err2 != nil {
+			dependency.Error = err2.Error()
+			continue
+		}
+
+		childChain := append(append([]string{}, chain...), dependency.FilePath)
+
+		dependency.Dependencies, err2 = resolver.resolve(content, dependency.FilePath, filepath.Dir(dependency.FilePath), childChain)
+		if err2 != nil {
+			dependency.Error = err2.Error()
+		}
+	}
+
+	return
+}
+
+func (graph *DependencyGraph) Write(format DependencyFormat, output io.Writer) (err error) {
+	switch format {
+	case DependencyFormatTree:
+		err = graph.writeTree(output)
+	case DependencyFormatJSON:
+		err = graph.writeJSON(output)
+	case DependencyFormatDOT:
+		err = graph.writeDOT(output)
+	default:
+		err = ErrDependencyFormatUnrecognized
+	}
+
+	return
+}
+
+func (graph *DependencyGraph) displayPath(filePath string) string {
+	if filePath == "" {
+		return coverageInputFilePath
+	}
+
+	if relPath, err := filepath.Rel(graph.rootDir, filePath); err == nil {
+		return filepath.ToSlash(relPath)
+	}
+
+	return filePath
+}
+
+func (graph *DependencyGraph) writeTree(output io.Writer) (err error) {
+	if _, err = fmt.Fprintln(output, graph.displayPath(graph.FilePath)); err != nil {
+		return
+	}
+
+	if err = graph.writeTreeDependencies(output, graph.Dependencies, ""); err != nil {
+		return
+	}
+
+	if len(graph.Missing) > 0 {
+		if err = graph.writeTreeSummary(output, "missing files", graph.Missing); err != nil {
+			return
+		}
+	}
+
+	if len(graph.Repeated) > 0 {
+		err = graph.writeTreeSummary(output, "files included more than once", graph.Repeated)
+	}
+
+	return
+}
+
+func (graph *DependencyGraph) writeTreeDependencies(output io.Writer, dependencies []*Dependency, prefix string) (err error) {
+	for i, dependency := range dependencies {
+		branch, childPrefix := "├── ", "│   "
+		if i == len(dependencies)-1 {
+			branch, childPrefix = "└── ", "    "
+		}
+
+		if _, err = fmt.Fprintf(output, "%s%s%s%s\n", prefix, branch, graph.displayPath(dependency.FilePath), dependency.annotation()); err != nil {
+			return
+		}
+
+		if err = graph.writeTreeDependencies(output, dependency.Dependencies, prefix+childPrefix); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (graph *DependencyGraph) writeTreeSummary(output io.Writer, title string, filePaths []string) (err error) {
+	if _, err = fmt.Fprintf(output, "\n%s:\n", title); err != nil {
+		return
+	}
+
+	for _, filePath := range filePaths {
+		if _, err = fmt.Fprintf(output, "  %s\n", graph.displayPath(filePath)); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (graph *DependencyGraph) writeJSON(output io.Writer) (err error) {
+	encoder := json.NewEncoder(output)
+	encoder.SetIndent("", "\t")
+
+	err = encoder.Encode(graph)
+
+	return
+}
+
+func (graph *DependencyGraph) writeDOT(output io.Writer) (err error) {
+	var builder strings.Builder
+
+	builder.WriteString("digraph dependencies {\n")
+	builder.WriteString("\t" + strconv.Quote(graph.displayPath(graph.FilePath)) + " [shape=box, style=bold];\n")
+
+	nodesSeen := make(map[string]bool)
+	edgesSeen := make(map[string]bool)
+
+	graph.writeDOTDependencies(&builder, graph.FilePath, graph.Dependencies, nodesSeen, edgesSeen)
+
+	builder.WriteString("}\n")
+
+	_, err = io.WriteString(output, builder.String())
+
+	return
+}
+
+func (graph *DependencyGraph) writeDOTDependencies(builder *strings.Builder, parentFilePath string, dependencies []*Dependency, nodesSeen, edgesSeen map[string]bool) {
+	parentID := strconv.Quote(graph.displayPath(parentFilePath))
+
+	for _, dependency := range dependencies {
+		id := strconv.Quote(graph.displayPath(dependency.FilePath))
+
+		if !nodesSeen[dependency.FilePath] {
+			nodesSeen[dependency.FilePath] = true
+
+			attributes := []string{"shape=note"}
+			if dependency.Code {
+				attributes[0] = "shape=box"
+			}
+
+			switch {
+			case dependency.Missing:
+				attributes = append(attributes, "style=dashed", "color=red")
+			case dependency.Repeated:
+				attributes = append(attributes, "color=orange")
+			}
+
+			builder.WriteString("\t" + id + " [" + strings.Join(attributes, ", ") + "];\n")
+		}
+
+		edge := parentID + " -> " + id
+		if !edgesSeen[edge] {
+			edgesSeen[edge] = true
+
+			if dependency.Cycle {
+				builder.WriteString("\t" + edge + " [color=red, label=\"cycle\"];\n")
+			} else {
+				builder.WriteString("\t" + edge + ";\n")
+			}
+		}
+
+		graph.writeDOTDependencies(builder, dependency.FilePath, dependency.Dependencies, nodesSeen, edgesSeen)
+	}
+}
+
+func (dependency *Dependency) annotation() string {
+	var notes []string
+
+	if dependency.Missing {
+		notes = append(notes, "missing")
+	}
+
+	if dependency.Repeated {
+		notes = append(notes, "repeated")
+	}
+
+	if dependency.Cycle {
+		notes = append(notes, "cycle")
+	}
+
+	if dependency.Error != "" {
+		notes = append(notes, "error: "+dependency.Error)
+	}
+
+	if len(notes) == 0 {
+		return ""
+	}
+
+	return " [" + strings.Join(notes, ", ") + "]"
+}
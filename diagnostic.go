@@ -0,0 +1,9 @@
+package dorklang
+
+type Diagnostic struct {
+	Start   Position `json:"start"`
+	End     Position `json:"end"`
+	Message string   `json:"message"`
+}
+
+type DiagnosticCollection []Diagnostic
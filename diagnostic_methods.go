@@ -0,0 +1,31 @@
+package dorklang
+
+import "sort"
+
+func (diagnostic Diagnostic) String() string {
+	return diagnostic.Start.String() + ": " + diagnostic.Message
+}
+
+func (collection DiagnosticCollection) Len() int {
+	return len(collection)
+}
+
+func (collection DiagnosticCollection) Swap(i, j int) {
+	collection[i], collection[j] = collection[j], collection[i]
+}
+
+func (collection DiagnosticCollection) Less(i, j int) bool {
+	if collection[i].Start.FilePath != collection[j].Start.FilePath {
+		return collection[i].Start.FilePath < collection[j].Start.FilePath
+	}
+
+	if collection[i].Start.Offset != collection[j].Start.Offset {
+		return collection[i].Start.Offset < collection[j].Start.Offset
+	}
+
+	return collection[i].Message < collection[j].Message
+}
+
+func (collection DiagnosticCollection) Sort() {
+	sort.Sort(collection)
+}
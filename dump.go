@@ -0,0 +1,20 @@
+package dorklang
+
+type DumpFormat int
+
+const (
+	DumpFormatJSON DumpFormat = iota
+	DumpFormatSExpression
+)
+
+type dumpNode struct {
+	Lexeme      string     `json:"lexeme"`
+	Start       *Position  `json:"start,omitempty"`
+	End         *Position  `json:"end,omitempty"`
+	CloseStart  *Position  `json:"closeStart,omitempty"`
+	CloseEnd    *Position  `json:"closeEnd,omitempty"`
+	Data        *string    `json:"data,omitempty"`
+	Include     string     `json:"include,omitempty"`
+	Directories []string   `json:"directories,omitempty"`
+	Children    []dumpNode `json:"children,omitempty"`
+}
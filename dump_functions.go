@@ -0,0 +1,176 @@
+package dorklang
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+)
+
+func ParseDumpFormat(s string) (format DumpFormat, err error) {
+	switch strings.ToLower(s) {
+	case "json":
+		format = DumpFormatJSON
+	case "sexp", "s-expression", "sexpr":
+		format = DumpFormatSExpression
+	default:
+		err = ErrDumpFormatUnrecognized
+	}
+
+	return
+}
+
+func DumpTokens(input []byte, options InterpretCodeOptions, format DumpFormat, output io.Writer) (err error) {
+	var tokens tokenCollection
+
+	err = withWorkingDir(options.WorkingDir, func() (err error) {
+		tokens, err = produceCleanTokens(input, options)
+
+		return
+	})
+	if err != nil {
+		return
+	}
+
+	node := dumpNode{
+		Lexeme:   "TOKENS",
+		Children: dumpNodesFromTokens(tokens),
+	}
+
+	err = node.write(format, output)
+
+	return
+}
+
+func DumpTree(input []byte, options InterpretCodeOptions, format DumpFormat, output io.Writer) (err error) {
+	var tr *tree
+
+	err = withWorkingDir(options.WorkingDir, func() (err error) {
+		var tokens tokenCollection
+
+		tokens, err = produceCleanTokens(input, options)
+		if err != nil {
+			return
+		}
+
+		tr, err = produceTree(tokens, options)
+
+		return
+	})
+	if err != nil {
+		return
+	}
+
+	node := dumpNodeFromTreeNode(tr.rootNode)
+
+	err = node.write(format, output)
+
+	return
+}
+
+func dumpNodesFromTokens(tokens tokenCollection) (nodes []dumpNode) {
+	nodes = make([]dumpNode, 0, len(tokens))
+
+	for _, t := range tokens {
+		nodes = append(nodes, dumpNodeFromToken(t))
+	}
+
+	return
+}
+
+func dumpNodeFromToken(t token) (node dumpNode) {
+	node = dumpNode{
+		Lexeme: t.lex.name(),
+	}
+
+	node.setSpan(t.span)
+
+	switch t.lex {
+	case filePathLexeme,
+		setLiteralLexeme,
+		pushStringLexeme,
+		startProcedureSectionLexeme,
+		callProcedureLexeme,
+		setOverflowModeLexeme:
+		data := string(t.data)
+		node.Data = &data
+	case parentLexeme:
+		for _, dir := range bytes.Split(t.data, tokenDataSeparatorByteSlice) {
+			node.Directories = append(node.Directories, string(dir))
+		}
+
+		if len(t.childCollection) > 0 {
+			node.Include = t.childCollection[0].span.start.FilePath
+		}
+
+		node.Children = dumpNodesFromTokens(t.childCollection)
+	}
+
+	return
+}
+
+func dumpNodeFromTreeNode(treeNode treeNode) (node dumpNode) {
+	node = dumpNode{
+		Lexeme: treeNode.getLexeme().name(),
+	}
+
+	node.setSpan(treeNode.getSpan())
+
+	switch treeNode := treeNode.(type) {
+	case *parentTreeNode:
+		if treeNode.endSpan.start.IsValid() {
+			closeStart := treeNode.endSpan.start
+			closeEnd := treeNode.endSpan.end
+
+			node.CloseStart = &closeStart
+			node.CloseEnd = &closeEnd
+		}
+
+		if treeNode.lexeme == startProcedureSectionLexeme {
+			data := string(treeNode.data)
+			node.Data = &data
+		}
+
+		node.Children = make([]dumpNode, 0, len(treeNode.childNodes))
+
+		for _, childNode := range treeNode.childNodes {
+			node.Children = append(node.Children, dumpNodeFromTreeNode(childNode))
+		}
+	case *terminalTreeNode:
+		switch treeNode.lexeme {
+		case filePathLexeme,
+			setLiteralLexeme,
+			pushStringLexeme,
+			callProcedureLexeme,
+			setOverflowModeLexeme:
+			data := string(treeNode.data)
+			node.Data = &data
+		case changeDirLexeme:
+			node.Directories = []string{string(treeNode.data)}
+		}
+	}
+
+	return
+}
+
+func writeDumpJSON(node dumpNode, output io.Writer) (err error) {
+	encoder := json.NewEncoder(output)
+	encoder.SetIndent("", "\t")
+
+	err = encoder.Encode(node)
+
+	return
+}
+
+func writeSExpressionPosition(builder *strings.Builder, name string, position *Position) {
+	builder.WriteString(" (")
+	builder.WriteString(name)
+	builder.WriteByte(' ')
+	builder.WriteString(strconv.Itoa(position.Offset))
+	builder.WriteByte(' ')
+	builder.WriteString(strconv.Itoa(position.Line))
+	builder.WriteByte(' ')
+	builder.WriteString(strconv.Itoa(position.Column))
+	builder.WriteByte(')')
+}
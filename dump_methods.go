@@ -0,0 +1,102 @@
+package dorklang
+
+import (
+	"io"
+	"strconv"
+	"strings"
+)
+
+func (format DumpFormat) String() string {
+	switch format {
+	case DumpFormatJSON:
+		return "json"
+	case DumpFormatSExpression:
+		return "sexp"
+	}
+
+	return "unknown"
+}
+
+func (node *dumpNode) setSpan(span sourceSpan) {
+	if !span.start.IsValid() {
+		return
+	}
+
+	start := span.start
+	end := span.end
+
+	node.Start = &start
+	node.End = &end
+}
+
+func (node dumpNode) write(format DumpFormat, output io.Writer) (err error) {
+	switch format {
+	case DumpFormatJSON:
+		err = writeDumpJSON(node, output)
+	case DumpFormatSExpression:
+		var builder strings.Builder
+
+		node.writeSExpression(&builder, 0)
+		builder.WriteByte('\n')
+
+		_, err = io.WriteString(output, builder.String())
+	default:
+		err = ErrDumpFormatUnrecognized
+	}
+
+	return
+}
+
+func (node dumpNode) writeSExpression(builder *strings.Builder, indent int) {
+	builder.WriteByte('(')
+	builder.WriteString(node.Lexeme)
+
+	if node.Start != nil {
+		builder.WriteString(" (file ")
+		builder.WriteString(strconv.Quote(node.Start.FilePath))
+		builder.WriteByte(')')
+
+		writeSExpressionPosition(builder, "start", node.Start)
+		writeSExpressionPosition(builder, "end", node.End)
+	}
+
+	if node.CloseStart != nil {
+		writeSExpressionPosition(builder, "close-start", node.CloseStart)
+		writeSExpressionPosition(builder, "close-end", node.CloseEnd)
+	}
+
+	if node.Data != nil {
+		builder.WriteString(" (data ")
+		builder.WriteString(strconv.Quote(*node.Data))
+		builder.WriteByte(')')
+	}
+
+	if node.Include != "" {
+		builder.WriteString(" (include ")
+		builder.WriteString(strconv.Quote(node.Include))
+		builder.WriteByte(')')
+	}
+
+	if len(node.Directories) > 0 {
+		builder.WriteString(" (directories")
+
+		for _, dir := range node.Directories {
+			builder.WriteByte(' ')
+			builder.WriteString(strconv.Quote(dir))
+		}
+
+		builder.WriteByte(')')
+	}
+
+	for _, child := range node.Children {
+		builder.WriteByte('\n')
+
+		for i := 0; i <= indent; i++ {
+			builder.WriteString("  ")
+		}
+
+		child.writeSExpression(builder, indent+1)
+	}
+
+	builder.WriteByte(')')
+}
@@ -0,0 +1,154 @@
+" Vim syntax file
+" Language: dorklang
+" Generated by the syntax command of the dorklang interpreter.
+
+if exists("b:current_syntax")
+	finish
+endif
+
+syntax match dorkModifier /\V%/
+syntax match dorkLoop /\V>/
+syntax match dorkLoop /\V</
+syntax match dorkContext /\V]/
+syntax match dorkContext /\V[/
+syntax match dorkContext /\V)/
+syntax match dorkContext /\V(/
+syntax match dorkFile /\V|/
+syntax match dorkFile /\V,/
+syntax match dorkFile /\V./
+syntax match dorkStack /\Vi/
+syntax match dorkProcedure /\V&/
+syntax match dorkStack /\Vg/
+syntax match dorkStack /\Vp/
+syntax match dorkStack /\Vt/
+syntax match dorkStack /\Vo/
+syntax match dorkStack /\Vz/
+syntax match dorkStack /\Vd/
+syntax match dorkStack /\Vr/
+syntax match dorkStack /\Vx/
+syntax match dorkStack /\Vs/
+syntax match dorkStack /\V#/
+syntax match dorkStack /\V;/
+syntax match dorkStack /\V:/
+syntax match dorkStack /\V\%d36/
+syntax match dorkLogic /\V\\/
+syntax match dorkValue /\V@/
+syntax match dorkValue /\V`/
+syntax match dorkValue /\V"/
+syntax match dorkValue /\V'/
+syntax match dorkValue /\V~/
+syntax match dorkInputOutput /\V?/
+syntax match dorkInputOutput /\V!/
+syntax match dorkBitwise /\Vl/
+syntax match dorkBitwise /\Vh/
+syntax match dorkBitwise /\Vb/
+syntax match dorkBitwise /\Vn/
+syntax match dorkArithmetic /\V\%d94/
+syntax match dorkArithmetic /\V*/
+syntax match dorkArithmetic /\Vm/
+syntax match dorkArithmetic /\V\//
+syntax match dorkArithmetic /\V-/
+syntax match dorkArithmetic /\V+/
+syntax match dorkCondition /\V%./
+syntax match dorkCondition /\V%!/
+syntax match dorkCondition /\V%?/
+syntax match dorkLoop /\V>>/
+syntax match dorkLoop /\V<</
+syntax match dorkProcedure /\V%)/
+syntax match dorkProcedure /\V%(/
+syntax match dorkContext /\V%]/
+syntax match dorkContext /\V%[/
+syntax match dorkContext /\V]]/
+syntax match dorkContext /\V[[/
+syntax match dorkContext /\V))/
+syntax match dorkContext /\V((/
+syntax match dorkStack /\V%|/
+syntax match dorkStack /\V||/
+syntax match dorkStack /\Vii/
+syntax match dorkLoop /\V%c/
+syntax match dorkLoop /\V%b/
+syntax match dorkStack /\Vgg/
+syntax match dorkStack /\V%g/
+syntax match dorkStack /\V%t/
+syntax match dorkStack /\V%d/
+syntax match dorkStack /\V%s/
+syntax match dorkStack /\Vss/
+syntax match dorkStack /\V##/
+syntax match dorkStack /\V%;/
+syntax match dorkStack /\V%:/
+syntax match dorkStack /\V%\%d36/
+syntax match dorkStack /\V\%d36\%d36/
+syntax match dorkLogic /\V%>/
+syntax match dorkLogic /\V%</
+syntax match dorkLogic /\V%=/
+syntax match dorkLogic /\V%\%d94/
+syntax match dorkLogic /\V%v/
+syntax match dorkLogic /\V%&/
+syntax match dorkValue /\V@@/
+syntax match dorkValue /\V``/
+syntax match dorkArithmetic /\V%#/
+syntax match dorkValue /\V%"/
+syntax match dorkValue /\V%'/
+syntax match dorkValue /\V""/
+syntax match dorkValue /\V''/
+syntax match dorkInputOutput /\V??/
+syntax match dorkInputOutput /\V!!/
+syntax match dorkBitwise /\V%l/
+syntax match dorkBitwise /\Vll/
+syntax match dorkBitwise /\V%h/
+syntax match dorkBitwise /\Vhh/
+syntax match dorkBitwise /\V%x/
+syntax match dorkBitwise /\V%o/
+syntax match dorkBitwise /\V%a/
+syntax match dorkArithmetic /\V\%d94\%d94/
+syntax match dorkArithmetic /\V%*/
+syntax match dorkArithmetic /\V**/
+syntax match dorkArithmetic /\V%m/
+syntax match dorkArithmetic /\Vmm/
+syntax match dorkArithmetic /\V%\//
+syntax match dorkArithmetic /\V\/\//
+syntax match dorkArithmetic /\V%-/
+syntax match dorkArithmetic /\V--/
+syntax match dorkArithmetic /\V%+/
+syntax match dorkArithmetic /\V++/
+syntax match dorkCondition /\V%??/
+syntax match dorkLogic /\V%>=/
+syntax match dorkLogic /\V%<=/
+syntax match dorkLogic /\V%<>/
+syntax match dorkLogic /\V%\%d94\%d94/
+syntax match dorkLogic /\V%vv/
+syntax match dorkLogic /\V%&&/
+syntax match dorkValue /\V%""/
+syntax match dorkValue /\V%''/
+syntax match dorkBitwise /\V%xx/
+syntax match dorkBitwise /\V%oo/
+syntax match dorkBitwise /\V%aa/
+syntax match dorkArithmetic /\V%**/
+syntax match dorkArithmetic /\V%mm/
+syntax match dorkArithmetic /\V%\/\//
+syntax match dorkArithmetic /\V%--/
+syntax match dorkArithmetic /\V%++/
+syntax match dorkValue /\v0[xX]\x*|\d+/
+syntax match dorkArithmetic /\v\%#\w*/
+syntax match dorkStack /\v\%\{(\\u\{[^}]*\}|\\.|[^\\}])*\}/
+syntax match dorkProcedure /\v\&\w+/
+syntax match dorkProcedure /\v\%\(\w*/
+syntax region dorkComment start=/\V{/ end=/\V}/ contains=dorkComment
+syntax region dorkInclude start=/\V{{/ end=/\V}}/
+
+highlight default link dorkArithmetic Operator
+highlight default link dorkLogic Conditional
+highlight default link dorkBitwise Operator
+highlight default link dorkValue Number
+highlight default link dorkInputOutput Function
+highlight default link dorkStack Type
+highlight default link dorkFile Function
+highlight default link dorkContext Delimiter
+highlight default link dorkLoop Repeat
+highlight default link dorkCondition Conditional
+highlight default link dorkProcedure Function
+highlight default link dorkModifier Special
+highlight default link dorkComment Comment
+highlight default link dorkInclude Include
+
+let b:current_syntax = "dork"
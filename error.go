@@ -13,4 +13,47 @@ var (
 	ErrLexemeSectionStackEmpty    = errors.New("cannot load a lexeme from the section stack")
 	ErrLexemeSectionStackNoMatch  = errors.New("lexeme from the section stack does not match expected value")
 	ErrMemoryCellConversionFailed = errors.New("cannot convert value to memoryCell")
+
+	ErrDumpFormatUnrecognized = errors.New("dump format is not recognized")
+
+	ErrMinifyTokensChanged = errors.New("minifying would change the tokens produced from the source")
+
+	ErrIncludeCycle         = errors.New("files include each other in a cycle")
+	ErrIncludeDepthExceeded = errors.New("files are included too deeply")
+
+	ErrDependencyFormatUnrecognized = errors.New("dependency format is not recognized")
+
+	ErrSyntaxFormatUnrecognized  = errors.New("syntax format is not recognized")
+	ErrSyntaxTableMarkersUnfound = errors.New("cannot find the markers for the syntax table")
+
+	ErrNumericLiteralInvalid    = errors.New("numeric literal is not valid")
+	ErrNumericLiteralOutOfRange = errors.New("numeric literal does not fit into 64 bits")
+
+	ErrStringLiteralEscapeInvalid = errors.New("string literal has an invalid escape sequence")
+
+	ErrProcedureNameInvalid = errors.New("procedure name must be made up of between 1 and 32 ASCII letters, digits and underscores")
+	ErrProcedureRedefined   = errors.New("procedure is defined more than once")
+	ErrProcedureUnfound     = errors.New("cannot find procedure")
+	ErrCallDepthExceeded    = errors.New("procedures are called too deeply")
+
+	ErrLoopCommandOutsideLoop = errors.New("break and continue commands can only be used inside a loop")
+
+	ErrDivisionByZero                 = errors.New("cannot divide by zero")
+	ErrDivisionByZeroModeUnrecognized = errors.New("division-by-zero mode is not recognized")
+
+	ErrOverflow                 = errors.New("arithmetic overflowed the current value")
+	ErrOverflowModeUnrecognized = errors.New("overflow mode is not recognized")
+
+	ErrShiftOutOfRange = errors.New("shift is too large")
+
+	ErrBigIntegerOutOfRange = errors.New("value is too large or negative to be used as a count or depth")
+
+	ErrCellWidthUnrecognized    = errors.New("cell width is not recognized")
+	ErrCellWidthWithBigIntegers = errors.New("cell width cannot be changed in big-integer mode")
+)
+
+// used to leave loops early, so they are never returned from a tree
+var (
+	errLoopBreak    = errors.New("break out of loop")
+	errLoopContinue = errors.New("continue loop")
 )
@@ -0,0 +1,42 @@
+package dorklang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// the examples are keyed by their file paths, so a failing example can be found from the name of its test
+func exampleSources(t *testing.T) map[string][]byte {
+	filePaths, err := filepath.Glob(filepath.Join("examples", "*"+FileExtensionForCode))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(filePaths) == 0 {
+		t.Fatal("cannot find any examples")
+	}
+
+	sources := make(map[string][]byte, len(filePaths))
+
+	for _, filePath := range filePaths {
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sources[filePath] = content
+	}
+
+	return sources
+}
+
+func sourceInputs(t *testing.T, edgeCases map[string]string) map[string][]byte {
+	inputs := exampleSources(t)
+
+	for name, input := range edgeCases {
+		inputs[name] = []byte(input)
+	}
+
+	return inputs
+}
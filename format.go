@@ -0,0 +1,6 @@
+package dorklang
+
+const (
+	formatIndent      = "\t"
+	formatMaxNewlines = 2
+)
@@ -0,0 +1,111 @@
+package dorklang
+
+import (
+	"bytes"
+	"strings"
+)
+
+func FormatCode(input []byte) (output []byte, err error) {
+	source := input
+
+	tokens, err := produceTokens(input, "")
+	if err != nil {
+		return
+	}
+
+	var builder strings.Builder
+
+	depth := 0
+	newlines := 0
+	previousLexeme := invalidLexeme
+	var previousData []byte
+
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+
+		switch t.lex {
+		case startProgramLexeme,
+			endProgramLexeme,
+			emptyLexeme:
+			continue
+		case separatorLexeme:
+			if n := bytes.Count(source[t.span.start.Offset:t.span.end.Offset], []byte{'\n'}); n > newlines {
+				newlines = n
+			}
+
+			continue
+		}
+
+		text := string(source[t.span.start.Offset:t.span.end.Offset])
+
+		switch t.lex {
+		case startCommentSectionLexeme,
+			startReadFileSectionLexeme:
+			{
+				j := tokens.matchingSectionEnd(i)
+				text = string(source[t.span.start.Offset:tokens[j].span.end.Offset])
+				i = j
+			}
+		case endAdditionSectionLexeme,
+			endSubtractionSectionLexeme,
+			endMultiplicationSectionLexeme,
+			endDivisionSectionLexeme,
+			endModuloSectionLexeme,
+			endProcedureSectionLexeme,
+			endJumpIfPositiveSectionLexeme,
+			endJumpIfZeroSectionLexeme,
+			endIfSectionLexeme,
+			elseSectionLexeme:
+			if depth > 0 {
+				depth--
+			}
+		}
+
+		if builder.Len() > 0 && newlines > 0 {
+			if newlines > formatMaxNewlines {
+				newlines = formatMaxNewlines
+			}
+
+			builder.WriteString(strings.Repeat("\n", newlines))
+			builder.WriteString(strings.Repeat(formatIndent, depth))
+		} else if builder.Len() > 0 {
+			switch {
+			case t.lex == startCommentSectionLexeme,
+				t.lex == startReadFileSectionLexeme,
+				previousLexeme == startCommentSectionLexeme,
+				previousLexeme == startReadFileSectionLexeme,
+				lexemeAbsorbsText(previousLexeme, previousData, text):
+				builder.WriteByte(' ')
+			}
+		}
+
+		builder.WriteString(text)
+
+		newlines = 0
+		previousLexeme = t.lex
+		previousData = t.data
+
+		switch t.lex {
+		case startAdditionSectionLexeme,
+			startSubtractionSectionLexeme,
+			startMultiplicationSectionLexeme,
+			startDivisionSectionLexeme,
+			startModuloSectionLexeme,
+			startProcedureSectionLexeme,
+			startJumpIfPositiveSectionLexeme,
+			startJumpIfZeroSectionLexeme,
+			startIfSectionLexeme,
+			startIfZeroSectionLexeme,
+			elseSectionLexeme:
+			depth++
+		}
+	}
+
+	if builder.Len() > 0 {
+		builder.WriteByte('\n')
+	}
+
+	output = []byte(builder.String())
+
+	return
+}
@@ -0,0 +1,67 @@
+package dorklang
+
+import (
+	"bytes"
+	"testing"
+)
+
+var formatCodeEdgeCases = map[string]string{
+	"empty":           ``,
+	"whitespace":      " \n\t\n ",
+	"merging":         `+ + ++ ++ - - ! !!`,
+	"string":          `%{Hello, { world \}!\n}%{\u{1F600}\\}:;`,
+	"comment":         "{ a comment with + and ( brackets }\n+{ another }+",
+	"include":         `{{mixins/printCurrentStack.dork}}{{ readFileText.txt }}`,
+	"procedure":       "%(countdown !! -< &countdown ~ > %)\n\n\n\n'+&countdown",
+	"else":            `%? + %! - %. %?? ( + %? ++ %! -- %. ) %.`,
+	"loops":           `+< - %b %c <<++>> >`,
+	"sections":        `((+++))[[++]]%[+%]([+])`,
+	"literals":        `26 0x1a 7 : 8 ;`,
+	"overflow":        `%#saturate 0 - %#wrap`,
+	"nested newlines": "+\n\n(\n+\n\n\n\n(\n-\n)\n)\n",
+}
+
+func TestFormatCodeKeepsTokens(t *testing.T) {
+	for name, input := range sourceInputs(t, formatCodeEdgeCases) {
+		t.Run(name, func(t *testing.T) {
+			output, err := FormatCode(input)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			inputTokens, err := produceTokens(input, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			outputTokens, err := produceTokens(output, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !inputTokens.equivalent(outputTokens) {
+				t.Errorf("tokens changed when formatting:\n%s", output)
+			}
+		})
+	}
+}
+
+func TestFormatCodeIsIdempotent(t *testing.T) {
+	for name, input := range sourceInputs(t, formatCodeEdgeCases) {
+		t.Run(name, func(t *testing.T) {
+			output, err := FormatCode(input)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			reformattedOutput, err := FormatCode(output)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(output, reformattedOutput) {
+				t.Errorf("formatting is not stable:\n%s\n---\n%s", output, reformattedOutput)
+			}
+		})
+	}
+}
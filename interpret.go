@@ -1,29 +1,51 @@
 package dorklang
 
 import (
+	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
 )
 
 const (
-	interpretCodeOptionsSaveStackMaxLen = 1 << 20 // 1_048_576
+	interpretCodeOptionsSaveStackMaxLen        = 1 << 20 // 1_048_576
+	interpretCodeOptionsMaxIncludeDepthDefault = 64
+	interpretCodeOptionsMaxCallDepthDefault    = 1024
 )
 
 type InterpretCodeOptions struct {
 	WorkingDir          string
+	FilePath            string
 	DebugMode           bool
 	SkipClean           bool
 	Input               io.Reader
 	Output              io.Writer
+	Coverage            *Coverage
+	MaxIncludeDepth     int
+	MaxCallDepth        int
+	DivisionByZero      DivisionByZeroMode
+	Overflow            OverflowMode
+	BigIntegers         bool
+	CellWidth           CellWidth
 	initialCurrentValue memoryCell
+	includeChain        []string
+	includeDepth        int
 	saveStackIndex      int
 	saveStacks          [2]memoryCellCollection
+	bigCells            *bigCellTable // shared with included files, so their values can be returned
 }
 
 var (
 	InterpretCodeDefaultOptions = InterpretCodeOptions{
 		DebugMode:           false,
 		SkipClean:           false,
+		MaxIncludeDepth:     interpretCodeOptionsMaxIncludeDepthDefault,
+		MaxCallDepth:        interpretCodeOptionsMaxCallDepthDefault,
+		DivisionByZero:      DivisionByZeroError,
+		Overflow:            OverflowWrap,
+		BigIntegers:         false,
+		CellWidth:           CellWidth64,
 		Input:               os.Stdin,
 		Output:              os.Stdout,
 		initialCurrentValue: 0,
@@ -34,12 +56,63 @@ var (
 func (options InterpretCodeOptions) Clone() InterpretCodeOptions {
 	return InterpretCodeOptions{
 		WorkingDir:          options.WorkingDir,
+		FilePath:            options.FilePath,
 		DebugMode:           options.DebugMode,
 		SkipClean:           options.SkipClean,
 		Input:               options.Input,
 		Output:              options.Output,
+		Coverage:            options.Coverage,
+		MaxIncludeDepth:     options.MaxIncludeDepth,
+		MaxCallDepth:        options.MaxCallDepth,
+		DivisionByZero:      options.DivisionByZero,
+		Overflow:            options.Overflow,
+		BigIntegers:         options.BigIntegers,
+		CellWidth:           options.CellWidth,
 		initialCurrentValue: options.initialCurrentValue,
+		includeChain:        options.includeChain,
+		includeDepth:        options.includeDepth,
 		saveStackIndex:      options.saveStackIndex,
 		saveStacks:          options.saveStacks,
+		bigCells:            options.bigCells,
 	}
 }
+
+func (options InterpretCodeOptions) withInclude(fileAbsPath string) (output InterpretCodeOptions, err error) {
+	includeChain := options.includeChain
+
+	if len(includeChain) == 0 && options.FilePath != "" {
+		var filePath string
+
+		filePath, err = filepath.Abs(options.FilePath)
+		if err != nil {
+			return
+		}
+
+		includeChain = []string{filePath}
+	}
+
+	for i, filePath := range includeChain {
+		if filePath == fileAbsPath {
+			cycle := append(append([]string{}, includeChain[i:]...), fileAbsPath)
+			err = fmt.Errorf("%w: %s", ErrIncludeCycle, strings.Join(cycle, " -> "))
+			return
+		}
+	}
+
+	maxIncludeDepth := options.MaxIncludeDepth
+	if maxIncludeDepth <= 0 {
+		maxIncludeDepth = interpretCodeOptionsMaxIncludeDepthDefault
+	}
+
+	if options.includeDepth >= maxIncludeDepth {
+		err = fmt.Errorf("%w: the limit of %d levels was exceeded: %s", ErrIncludeDepthExceeded, maxIncludeDepth, strings.Join(append(append([]string{}, includeChain...), fileAbsPath), " -> "))
+		return
+	}
+
+	output = options.Clone()
+	output.FilePath = fileAbsPath
+	output.includeChain = append(append([]string{}, includeChain...), fileAbsPath)
+	output.includeDepth = options.includeDepth + 1
+
+	return
+}
@@ -1,10 +1,18 @@
 package dorklang
 
 import (
+	"bytes"
+	"io"
 	"os"
 )
 
 func InterpretCode(input []byte, options InterpretCodeOptions) (output uint64, err error) {
+	output, err = InterpretCodeFromReader(bytes.NewReader(input), options)
+
+	return
+}
+
+func InterpretCodeFromReader(reader io.Reader, options InterpretCodeOptions) (output uint64, err error) {
 	initialDir, err := os.Getwd()
 	if err != nil {
 		return
@@ -15,15 +23,20 @@ func InterpretCode(input []byte, options InterpretCodeOptions) (output uint64, e
 		return
 	}
 
-	tokens, err := produceTokens(input)
+	var source *bytes.Buffer
+
+	if options.Coverage != nil {
+		source = new(bytes.Buffer)
+		reader = io.TeeReader(reader, source)
+	}
+
+	tokens, err := produceCleanTokensFromReader(reader, options)
 	if err != nil {
 		return
 	}
 
-	if !options.SkipClean {
-		if err = cleanTokens(tokens); err != nil {
-			return
-		}
+	if options.Coverage != nil {
+		options.Coverage.addSource(options.FilePath, source.Bytes())
 	}
 
 	if options.DebugMode {
@@ -35,6 +48,10 @@ func InterpretCode(input []byte, options InterpretCodeOptions) (output uint64, e
 		return
 	}
 
+	if options.Coverage != nil {
+		options.Coverage.addTree(tree)
+	}
+
 	outputMemoryCell, err := tree.Run()
 	if err != nil {
 		return
@@ -54,3 +71,51 @@ func InterpretCodeWithDefaultOptions(input []byte) (output uint64, err error) {
 
 	return
 }
+
+func produceCleanTokens(input []byte, options InterpretCodeOptions) (tokens tokenCollection, err error) {
+	tokens, err = produceCleanTokensFromReader(bytes.NewReader(input), options)
+
+	return
+}
+
+func produceCleanTokensFromReader(reader io.Reader, options InterpretCodeOptions) (tokens tokenCollection, err error) {
+	// whitespace has done its job once the commands are split, so a long program does not keep a token per gap
+	tokens, err = produceTokensFromReader(reader, options.FilePath, separatorLexeme)
+	if err != nil {
+		return
+	}
+
+	if !options.SkipClean {
+		if err = cleanTokens(tokens, options); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func withWorkingDir(workingDir string, callback func() error) (err error) {
+	if workingDir == "" {
+		err = callback()
+		return
+	}
+
+	initialDir, err := os.Getwd()
+	if err != nil {
+		return
+	}
+
+	if err = os.Chdir(workingDir); err != nil {
+		return
+	}
+
+	defer func() {
+		if err2 := os.Chdir(initialDir); err == nil {
+			err = err2
+		}
+	}()
+
+	err = callback()
+
+	return
+}
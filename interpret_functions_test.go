@@ -0,0 +1,170 @@
+package dorklang
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func interpretIncludeFiles(t *testing.T, files map[string]string, mainName string, skipClean bool, maxIncludeDepth int) (dir string, err error) {
+	dir = t.TempDir()
+
+	for name, content := range files {
+		if err = os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the working directory is not changed back when a program fails
+	initialDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		os.Chdir(initialDir)
+	})
+
+	options := InterpretCodeDefaultOptions.Clone()
+	options.WorkingDir = dir
+	options.FilePath = filepath.Join(dir, mainName)
+	options.SkipClean = skipClean
+	options.MaxIncludeDepth = maxIncludeDepth
+	options.Input = strings.NewReader("")
+	options.Output = &strings.Builder{}
+
+	_, err = InterpretCode([]byte(files[mainName]), options)
+
+	return
+}
+
+func TestInterpretCodeIncludeCycle(t *testing.T) {
+	testCases := []struct {
+		name  string
+		files map[string]string
+		cycle []string
+	}{
+		{
+			name:  "self",
+			files: map[string]string{"a.dork": "+ {{a.dork}}"},
+			cycle: []string{"a.dork", "a.dork"},
+		},
+		{
+			name:  "pair",
+			files: map[string]string{"a.dork": "+ {{b.dork}}", "b.dork": "+ {{a.dork}}"},
+			cycle: []string{"a.dork", "b.dork", "a.dork"},
+		},
+		{
+			name:  "after a file that is not in the cycle",
+			files: map[string]string{"a.dork": "{{b.dork}}", "b.dork": "{{c.dork}}", "c.dork": "{{b.dork}}"},
+			cycle: []string{"b.dork", "c.dork", "b.dork"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		for _, skipClean := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s/skip clean %t", testCase.name, skipClean), func(t *testing.T) {
+				dir, err := interpretIncludeFiles(t, testCase.files, "a.dork", skipClean, 0)
+				if !errors.Is(err, ErrIncludeCycle) {
+					t.Fatalf("expected %v, got %v", ErrIncludeCycle, err)
+				}
+
+				cycle := make([]string, len(testCase.cycle))
+				for i, name := range testCase.cycle {
+					cycle[i] = filepath.Join(dir, name)
+				}
+
+				if expected := strings.Join(cycle, " -> "); !strings.HasSuffix(err.Error(), expected) {
+					t.Errorf("expected the cycle %s, got %v", expected, err)
+				}
+			})
+		}
+	}
+}
+
+func TestInterpretCodeIncludeDepth(t *testing.T) {
+	files := map[string]string{
+		"a.dork": "+ {{b.dork}}",
+		"b.dork": "+ {{c.dork}}",
+		"c.dork": "+ {{d.dork}}",
+		"d.dork": "+",
+	}
+
+	testCases := []struct {
+		maxIncludeDepth int
+		exceeded        bool
+	}{
+		{1, true},
+		{2, true},
+		{3, false},
+		{0, false},
+	}
+
+	for _, testCase := range testCases {
+		for _, skipClean := range []bool{false, true} {
+			t.Run(fmt.Sprintf("limit %d/skip clean %t", testCase.maxIncludeDepth, skipClean), func(t *testing.T) {
+				_, err := interpretIncludeFiles(t, files, "a.dork", skipClean, testCase.maxIncludeDepth)
+
+				if testCase.exceeded {
+					if !errors.Is(err, ErrIncludeDepthExceeded) {
+						t.Errorf("expected %v, got %v", ErrIncludeDepthExceeded, err)
+					}
+				} else if err != nil {
+					t.Error(err)
+				}
+			})
+		}
+	}
+}
+
+func TestInterpreterExitStatusWithCellWidth(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds the interpreter")
+	}
+
+	dir := t.TempDir()
+	interpreterPath := filepath.Join(dir, "interpreter")
+
+	if output, err := exec.Command("go", "build", "-o", interpreterPath, "./interpreter").CombinedOutput(); err != nil {
+		t.Fatalf("%v: %s", err, output)
+	}
+
+	testCases := []struct {
+		source    string
+		cellWidth string
+		expected  int
+	}{
+		{"300", "8", 44},
+		{"256 +", "8", 1},
+		{"0 -", "8", 125},
+		{`%"" ++`, "8", 8},
+		{"65536 ++", "16", 8},
+		{"256 +", "64", 125},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.source+"/"+testCase.cellWidth, func(t *testing.T) {
+			sourcePath := filepath.Join(dir, "main"+FileExtensionForCode)
+			if err := os.WriteFile(sourcePath, []byte(testCase.source), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			err := exec.Command(interpreterPath, "-file", sourcePath, "-cell-width", testCase.cellWidth).Run()
+
+			status := 0
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				status = exitErr.ExitCode()
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			if status != testCase.expected {
+				t.Errorf("expected exit status %d, got %d", testCase.expected, status)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/theTardigrade/dorklang"
+)
+
+type command struct {
+	description string
+	run         func(args []string) error
+}
+
+var (
+	commands map[string]command
+)
+
+func init() {
+	commands = map[string]command{
+		"ast": {
+			description: "prints the tree built from the source file",
+			run:         runASTCommand,
+		},
+		"deps": {
+			description: "prints the files included by the source file and the files that they include",
+			run:         runDepsCommand,
+		},
+		"fmt": {
+			description: "prints the source file in canonical layout",
+			run:         runFormatCommand,
+		},
+		"lsp": {
+			description: "runs a language server for editors over standard input and output",
+			run:         runLSPCommand,
+		},
+		"minify": {
+			description: "prints the source file with comments and redundant whitespace removed",
+			run:         runMinifyCommand,
+		},
+		"stack": {
+			description: "prints the possible heights of both stacks at each command in the source file",
+			run:         runStackCommand,
+		},
+		"syntax": {
+			description: "generates the README command table and editor syntax definitions from the command registry",
+			run:         runSyntaxCommand,
+		},
+		"tokens": {
+			description: "prints the tokens produced from the source file",
+			run:         runTokensCommand,
+		},
+		"vet": {
+			description: "reports likely mistakes in the source file",
+			run:         runVetCommand,
+		},
+	}
+
+	flag.Usage = printUsage
+}
+
+func printUsage() {
+	output := flag.CommandLine.Output()
+
+	fmt.Fprintf(output, "Usage:\n  %s [flags]\n  %s <command> [flags] [file]\n\nCommands:\n", os.Args[0], os.Args[0])
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(output, "  %-10s %s\n", name, commands[name].description)
+	}
+
+	fmt.Fprintf(output, "\nFlags:\n")
+	flag.PrintDefaults()
+}
+
+func runCommand(name string, args []string) (err error) {
+	command, found := commands[name]
+	if !found {
+		err = fmt.Errorf("unknown command %q", name)
+		return
+	}
+
+	err = command.run(args)
+
+	return
+}
+
+func newCommandFlagSet(name string) *flag.FlagSet {
+	flagSet := flag.NewFlagSet(name, flag.ExitOnError)
+
+	flagSet.Usage = func() {
+		fmt.Fprintf(flagSet.Output(), "Usage:\n  %s %s [flags] [file]\n\n%s\n\nFlags:\n", os.Args[0], name, commands[name].description)
+		flagSet.PrintDefaults()
+	}
+
+	return flagSet
+}
+
+func readSourceFile(flagSet *flag.FlagSet) (fileContents []byte, fileAbsPath string, err error) {
+	filePath := *flagFile
+	if flagSet.NArg() > 0 {
+		filePath = flagSet.Arg(0)
+	}
+
+	fileAbsPath, err = filepath.Abs(filePath)
+	if err != nil {
+		return
+	}
+
+	fileContents, err = os.ReadFile(fileAbsPath)
+
+	return
+}
+
+// the commands see the same options as a run, so they evaluate the source in the same way
+func sourceFileOptions(fileAbsPath string, skipClean bool) (options dorklang.InterpretCodeOptions, err error) {
+	divisionByZero, err := dorklang.ParseDivisionByZeroMode(*flagDivisionByZero)
+	if err != nil {
+		return
+	}
+
+	overflow, err := dorklang.ParseOverflowMode(*flagOverflow)
+	if err != nil {
+		return
+	}
+
+	cellWidth, err := dorklang.ParseCellWidth(*flagCellWidth)
+	if err != nil {
+		return
+	}
+
+	options = dorklang.InterpretCodeOptions{
+		WorkingDir:      filepath.Dir(fileAbsPath),
+		FilePath:        fileAbsPath,
+		SkipClean:       skipClean,
+		MaxIncludeDepth: *flagMaxIncludeDepth,
+		MaxCallDepth:    *flagMaxCallDepth,
+		DivisionByZero:  divisionByZero,
+		Overflow:        overflow,
+		BigIntegers:     *flagBigIntegers,
+		CellWidth:       cellWidth,
+		Input:           os.Stdin,
+		Output:          os.Stdout,
+	}
+
+	return
+}
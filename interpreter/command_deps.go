@@ -0,0 +1,40 @@
+package main
+
+import (
+	"os"
+
+	"github.com/theTardigrade/dorklang"
+)
+
+func runDepsCommand(args []string) (err error) {
+	flagSet := newCommandFlagSet("deps")
+	formatName := flagSet.String("format", dorklang.DependencyFormatTree.String(), "the output format (tree, json or dot)")
+
+	if err = flagSet.Parse(args); err != nil {
+		return
+	}
+
+	format, err := dorklang.ParseDependencyFormat(*formatName)
+	if err != nil {
+		return
+	}
+
+	fileContents, fileAbsPath, err := readSourceFile(flagSet)
+	if err != nil {
+		return
+	}
+
+	options, err := sourceFileOptions(fileAbsPath, false)
+	if err != nil {
+		return
+	}
+
+	graph, err := dorklang.ResolveDependencies(fileContents, options)
+	if err != nil {
+		return
+	}
+
+	err = graph.Write(format, os.Stdout)
+
+	return
+}
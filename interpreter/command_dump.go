@@ -0,0 +1,49 @@
+package main
+
+import (
+	"io"
+	"os"
+
+	"github.com/theTardigrade/dorklang"
+)
+
+func runASTCommand(args []string) error {
+	return runDumpCommand("ast", args, dorklang.DumpTree)
+}
+
+func runTokensCommand(args []string) error {
+	return runDumpCommand("tokens", args, dorklang.DumpTokens)
+}
+
+func runDumpCommand(
+	name string,
+	args []string,
+	dump func([]byte, dorklang.InterpretCodeOptions, dorklang.DumpFormat, io.Writer) error,
+) (err error) {
+	flagSet := newCommandFlagSet(name)
+	formatName := flagSet.String("format", dorklang.DumpFormatJSON.String(), "the output format (json or sexp)")
+	skipClean := flagSet.Bool("skip-clean", *flagSkipClean, "determines whether to skip the cleaning-tokens stage")
+
+	if err = flagSet.Parse(args); err != nil {
+		return
+	}
+
+	format, err := dorklang.ParseDumpFormat(*formatName)
+	if err != nil {
+		return
+	}
+
+	fileContents, fileAbsPath, err := readSourceFile(flagSet)
+	if err != nil {
+		return
+	}
+
+	options, err := sourceFileOptions(fileAbsPath, *skipClean)
+	if err != nil {
+		return
+	}
+
+	err = dump(fileContents, options, format, os.Stdout)
+
+	return
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"os"
+
+	"github.com/theTardigrade/dorklang"
+)
+
+func runFormatCommand(args []string) (err error) {
+	flagSet := newCommandFlagSet("fmt")
+	write := flagSet.Bool("w", false, "determines whether to write the result back to the source file instead of printing it")
+
+	if err = flagSet.Parse(args); err != nil {
+		return
+	}
+
+	fileContents, fileAbsPath, err := readSourceFile(flagSet)
+	if err != nil {
+		return
+	}
+
+	output, err := dorklang.FormatCode(fileContents)
+	if err != nil {
+		return
+	}
+
+	if *write {
+		var fileInfo os.FileInfo
+
+		fileInfo, err = os.Stat(fileAbsPath)
+		if err != nil {
+			return
+		}
+
+		err = os.WriteFile(fileAbsPath, output, fileInfo.Mode())
+
+		return
+	}
+
+	_, err = os.Stdout.Write(output)
+
+	return
+}
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"os"
+
+	"github.com/theTardigrade/dorklang"
+)
+
+func runLSPCommand(args []string) (err error) {
+	flagSet := newCommandFlagSet("lsp")
+
+	if err = flagSet.Parse(args); err != nil {
+		return
+	}
+
+	err = dorklang.ServeLanguageServer(os.Stdin, os.Stdout)
+
+	return
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/theTardigrade/dorklang"
+)
+
+func runMinifyCommand(args []string) (err error) {
+	flagSet := newCommandFlagSet("minify")
+	clean := flagSet.Bool("clean", false, "determines whether to apply the cleaning-tokens rewrites before minifying")
+	write := flagSet.Bool("w", false, "determines whether to write the result back to the source file instead of printing it")
+	report := flagSet.Bool("report", false, "determines whether to print the size reduction to standard error")
+
+	if err = flagSet.Parse(args); err != nil {
+		return
+	}
+
+	fileContents, fileAbsPath, err := readSourceFile(flagSet)
+	if err != nil {
+		return
+	}
+
+	output, err := dorklang.MinifyCode(fileContents, *clean)
+	if err != nil {
+		return
+	}
+
+	if *report {
+		var reduction float64
+		if len(fileContents) > 0 {
+			reduction = float64(len(fileContents)-len(output)) / float64(len(fileContents)) * 100
+		}
+
+		fmt.Fprintf(os.Stderr, "%s: %d -> %d bytes (%.1f%% smaller)\n", fileAbsPath, len(fileContents), len(output), reduction)
+	}
+
+	if *write {
+		var fileInfo os.FileInfo
+
+		fileInfo, err = os.Stat(fileAbsPath)
+		if err != nil {
+			return
+		}
+
+		err = os.WriteFile(fileAbsPath, output, fileInfo.Mode())
+
+		return
+	}
+
+	_, err = os.Stdout.Write(output)
+
+	return
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/theTardigrade/dorklang"
+)
+
+func runStackCommand(args []string) (err error) {
+	flagSet := newCommandFlagSet("stack")
+	formatName := flagSet.String("format", "text", "the output format (text or json)")
+	skipClean := flagSet.Bool("skip-clean", *flagSkipClean, "determines whether to skip the cleaning-tokens stage")
+
+	if err = flagSet.Parse(args); err != nil {
+		return
+	}
+
+	fileContents, fileAbsPath, err := readSourceFile(flagSet)
+	if err != nil {
+		return
+	}
+
+	options, err := sourceFileOptions(fileAbsPath, *skipClean)
+	if err != nil {
+		return
+	}
+
+	report, err := dorklang.AnalyseStackDepth(fileContents, options)
+	if err != nil {
+		return
+	}
+
+	switch *formatName {
+	case "text":
+		err = report.WriteText(os.Stdout)
+	case "json":
+		err = report.WriteJSON(os.Stdout)
+	default:
+		err = fmt.Errorf("unknown format %q", *formatName)
+	}
+	if err != nil {
+		return
+	}
+
+	if len(report.Diagnostics) > 0 {
+		os.Exit(1)
+	}
+
+	return
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	"github.com/theTardigrade/dorklang"
+)
+
+func runSyntaxCommand(args []string) (err error) {
+	flagSet := newCommandFlagSet("syntax")
+	formatName := flagSet.String("format", dorklang.SyntaxFormatMarkdown.String(), "the output format when no files are given (markdown, textmate or vim)")
+	readmePath := flagSet.String("readme", "", "the README file in which to replace the syntax table")
+	textMatePath := flagSet.String("textmate", "", "the file to which to write the TextMate grammar")
+	vimPath := flagSet.String("vim", "", "the file to which to write the vim syntax definition")
+
+	if err = flagSet.Parse(args); err != nil {
+		return
+	}
+
+	if *readmePath == "" && *textMatePath == "" && *vimPath == "" {
+		var format dorklang.SyntaxFormat
+
+		format, err = dorklang.ParseSyntaxFormat(*formatName)
+		if err != nil {
+			return
+		}
+
+		err = dorklang.WriteSyntax(format, os.Stdout)
+
+		return
+	}
+
+	if *readmePath != "" {
+		var readme []byte
+
+		readme, err = os.ReadFile(*readmePath)
+		if err != nil {
+			return
+		}
+
+		readme, err = dorklang.ReplaceSyntaxTable(readme)
+		if err != nil {
+			return
+		}
+
+		if err = writeSyntaxFile(*readmePath, readme); err != nil {
+			return
+		}
+	}
+
+	for format, filePath := range map[dorklang.SyntaxFormat]string{
+		dorklang.SyntaxFormatTextMate: *textMatePath,
+		dorklang.SyntaxFormatVim:      *vimPath,
+	} {
+		if filePath == "" {
+			continue
+		}
+
+		var buffer bytes.Buffer
+
+		if err = dorklang.WriteSyntax(format, &buffer); err != nil {
+			return
+		}
+
+		if err = writeSyntaxFile(filePath, buffer.Bytes()); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func writeSyntaxFile(filePath string, contents []byte) (err error) {
+	if err = os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return
+	}
+
+	err = os.WriteFile(filePath, contents, 0o644)
+
+	return
+}
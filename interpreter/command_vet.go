@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/theTardigrade/dorklang"
+)
+
+func runVetCommand(args []string) (err error) {
+	flagSet := newCommandFlagSet("vet")
+	skipClean := flagSet.Bool("skip-clean", *flagSkipClean, "determines whether to analyse the tokens without expanding included files first")
+
+	if err = flagSet.Parse(args); err != nil {
+		return
+	}
+
+	fileContents, fileAbsPath, err := readSourceFile(flagSet)
+	if err != nil {
+		return
+	}
+
+	options, err := sourceFileOptions(fileAbsPath, *skipClean)
+	if err != nil {
+		return
+	}
+
+	diagnostics, err := dorklang.VetCode(fileContents, options)
+	if err != nil {
+		return
+	}
+
+	for _, diagnostic := range diagnostics {
+		fmt.Println(diagnostic.String())
+	}
+
+	if len(diagnostics) > 0 {
+		os.Exit(1)
+	}
+
+	return
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"io"
+	"os"
+
+	"github.com/theTardigrade/dorklang"
+)
+
+func writeCoverage(coverage *dorklang.Coverage) (err error) {
+	if *flagCoverage {
+		if err = coverage.WriteText(os.Stderr); err != nil {
+			return
+		}
+	}
+
+	if *flagCoverageJSON != "" {
+		if err = writeCoverageFile(*flagCoverageJSON, coverage.WriteJSON); err != nil {
+			return
+		}
+	}
+
+	if *flagCoverageHTML != "" {
+		if err = writeCoverageFile(*flagCoverageHTML, coverage.WriteHTML); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func writeCoverageFile(filePath string, write func(io.Writer) error) (err error) {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	err = write(file)
+
+	return
+}
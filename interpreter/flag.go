@@ -7,10 +7,19 @@ import (
 )
 
 var (
-	flagFile           = flag.String("file", "source"+dorklang.FileExtensionForCode, "the path to the source file")
-	flagDebug          = flag.Bool("debug", false, "determines whether to print debug information")
-	flagSkipClean      = flag.Bool("skip-clean", false, "determines whether to skip the cleaning-tokens stage")
-	flagSkipExitStatus = flag.Bool("skip-exit-status", false, "determines whether to skip basing the program's exit code on its final current value")
+	flagFile            = flag.String("file", "source"+dorklang.FileExtensionForCode, "the path to the source file")
+	flagDebug           = flag.Bool("debug", false, "determines whether to print debug information")
+	flagSkipClean       = flag.Bool("skip-clean", false, "determines whether to skip the cleaning-tokens stage")
+	flagSkipExitStatus  = flag.Bool("skip-exit-status", false, "determines whether to skip basing the program's exit code on its final current value")
+	flagMaxIncludeDepth = flag.Int("max-include-depth", dorklang.InterpretCodeDefaultOptions.MaxIncludeDepth, "the maximum depth to which files can include other files")
+	flagMaxCallDepth    = flag.Int("max-call-depth", dorklang.InterpretCodeDefaultOptions.MaxCallDepth, "the maximum depth to which procedures can call other procedures")
+	flagDivisionByZero  = flag.String("division-by-zero", dorklang.InterpretCodeDefaultOptions.DivisionByZero.String(), "what happens when a value is divided by zero: error, zero or max")
+	flagOverflow        = flag.String("overflow", dorklang.InterpretCodeDefaultOptions.Overflow.String(), "what happens when arithmetic overflows the current value: wrap, saturate or trap")
+	flagCellWidth       = flag.String("cell-width", dorklang.InterpretCodeDefaultOptions.CellWidth.String(), "the number of bits in the current value and stack cells: 8, 16, 32 or 64")
+	flagBigIntegers     = flag.Bool("big-integers", false, "determines whether the current value and stack cells are arbitrary-precision integers")
+	flagCoverage        = flag.Bool("coverage", false, "determines whether to print a summary of the commands executed by the program")
+	flagCoverageJSON    = flag.String("coverage-json", "", "the path of a file to which a JSON coverage report should be written")
+	flagCoverageHTML    = flag.String("coverage-html", "", "the path of a file to which an HTML coverage report should be written")
 )
 
 func init() {
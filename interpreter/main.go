@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"os"
 	"path/filepath"
 
@@ -8,23 +9,65 @@ import (
 )
 
 func main() {
+	if flag.NArg() > 0 {
+		if err := runCommand(flag.Arg(0), flag.Args()[1:]); err != nil {
+			panic(err)
+		}
+
+		return
+	}
+
+	divisionByZero, err := dorklang.ParseDivisionByZeroMode(*flagDivisionByZero)
+	if err != nil {
+		panic(err)
+	}
+
+	overflow, err := dorklang.ParseOverflowMode(*flagOverflow)
+	if err != nil {
+		panic(err)
+	}
+
+	cellWidth, err := dorklang.ParseCellWidth(*flagCellWidth)
+	if err != nil {
+		panic(err)
+	}
+
 	fileAbsPath, err := filepath.Abs(*flagFile)
 	if err != nil {
 		panic(err)
 	}
 
-	fileContents, err := os.ReadFile(fileAbsPath)
+	file, err := os.Open(fileAbsPath)
 	if err != nil {
 		panic(err)
 	}
 
-	output, err := dorklang.InterpretCode(fileContents, dorklang.InterpretCodeOptions{
-		WorkingDir: filepath.Dir(fileAbsPath),
-		DebugMode:  *flagDebug,
-		SkipClean:  *flagSkipClean,
-		Input:      os.Stdin,
-		Output:     os.Stdout,
+	var coverage *dorklang.Coverage
+	if *flagCoverage || *flagCoverageJSON != "" || *flagCoverageHTML != "" {
+		coverage = dorklang.NewCoverage()
+	}
+
+	output, err := dorklang.InterpretCodeFromReader(file, dorklang.InterpretCodeOptions{
+		WorkingDir:      filepath.Dir(fileAbsPath),
+		FilePath:        fileAbsPath,
+		DebugMode:       *flagDebug,
+		SkipClean:       *flagSkipClean,
+		MaxIncludeDepth: *flagMaxIncludeDepth,
+		MaxCallDepth:    *flagMaxCallDepth,
+		DivisionByZero:  divisionByZero,
+		Overflow:        overflow,
+		BigIntegers:     *flagBigIntegers,
+		CellWidth:       cellWidth,
+		Input:           os.Stdin,
+		Output:          os.Stdout,
+		Coverage:        coverage,
 	})
+	file.Close()
+	if coverage != nil {
+		if err2 := writeCoverage(coverage); err2 != nil {
+			panic(err2)
+		}
+	}
 	if err != nil {
 		panic(err)
 	}
@@ -1,5 +1,7 @@
 package dorklang
 
+//go:generate go run ./interpreter syntax -readme README.md -textmate editors/textmate/dorklang.tmLanguage.json -vim editors/vim/syntax/dork.vim
+
 type lexeme uint64
 
 const (
@@ -14,10 +16,18 @@ const (
 	endMultiplicationSectionLexeme
 	startDivisionSectionLexeme
 	endDivisionSectionLexeme
+	startModuloSectionLexeme
+	endModuloSectionLexeme
+	startProcedureSectionLexeme
+	endProcedureSectionLexeme
 	startJumpIfPositiveSectionLexeme
 	endJumpIfPositiveSectionLexeme
 	startJumpIfZeroSectionLexeme
 	endJumpIfZeroSectionLexeme
+	startIfSectionLexeme
+	startIfZeroSectionLexeme
+	elseSectionLexeme
+	endIfSectionLexeme
 	startCommentSectionLexeme
 	endCommentSectionLexeme
 	startReadFileSectionLexeme
@@ -38,8 +48,26 @@ const (
 	divideEightLexeme
 	divideStackPairLexeme
 	divideStackWholeLexeme
+	moduloTwoLexeme
+	moduloEightLexeme
+	moduloStackPairLexeme
+	moduloStackWholeLexeme
 	squareLexeme
 	cubeLexeme
+	bitwiseAndStackPairLexeme
+	bitwiseAndStackWholeLexeme
+	bitwiseOrStackPairLexeme
+	bitwiseOrStackWholeLexeme
+	bitwiseXorStackPairLexeme
+	bitwiseXorStackWholeLexeme
+	bitwiseNotLexeme
+	bitCountLexeme
+	shiftLeftOneLexeme
+	shiftLeftEightLexeme
+	shiftLeftStackLexeme
+	shiftRightOneLexeme
+	shiftRightEightLexeme
+	shiftRightStackLexeme
 	setZeroLexeme
 	setOneByteLexeme
 	setEightByteLexeme
@@ -53,6 +81,8 @@ const (
 	setRandomMaxLexeme
 	setSecondTimestampLexeme
 	setNanosecondTimestampLexeme
+	setLiteralLexeme
+	setOverflowModeLexeme
 	printCharacterLexeme
 	printNumberLexeme
 	inputCharacterLexeme
@@ -61,12 +91,23 @@ const (
 	iotaFromOneLexeme
 	logicalAndStackPairLexeme
 	logicalAndStackWholeLexeme
+	logicalOrStackPairLexeme
+	logicalOrStackWholeLexeme
+	logicalXorStackPairLexeme
+	logicalXorStackWholeLexeme
+	equalStackPairLexeme
+	notEqualStackPairLexeme
+	lessStackPairLexeme
+	greaterStackPairLexeme
+	lessOrEqualStackPairLexeme
+	greaterOrEqualStackPairLexeme
 	writeStackToFileLexeme
 	readStackFromFileLexeme
 	deleteFileLexeme
 	clearStackLexeme
 	resetStateLexeme
 	pushStackLexeme
+	pushStringLexeme
 	countStackLexeme
 	popStackLastLexeme
 	popStackRandomLexeme
@@ -80,6 +121,19 @@ const (
 	shuffleStackLexeme
 	swapStackTopLexeme
 	reverseStackLexeme
+	duplicateStackTopLexeme
+	dropStackTopLexeme
+	overStackLexeme
+	rotateStackThreeLexeme
+	peekStackTopLexeme
+	pickStackLexeme
+	rollStackLexeme
+	giveStackTopLexeme
+	giveStackManyLexeme
+	giveStackWholeLexeme
+	callProcedureLexeme
+	breakLoopLexeme
+	continueLoopLexeme
 	filePathLexeme
 	invertLexeme
 	modifierLexeme
@@ -88,3 +142,1205 @@ const (
 	emptyLexeme     // used by cleanTokens to replace unnecessary tokens
 	parentLexeme    // used by cleanTokens to hold a child tokenCollection
 )
+
+type lexemeKind int
+
+const (
+	internalLexemeKind lexemeKind = iota
+	commandLexemeKind
+	modifierLexemeKind
+	sectionStartLexemeKind
+	sectionEndLexemeKind
+	literalLexemeKind
+)
+
+type lexemeCategory int
+
+const (
+	noLexemeCategory lexemeCategory = iota
+	arithmeticLexemeCategory
+	logicLexemeCategory
+	bitwiseLexemeCategory
+	valueLexemeCategory
+	inputOutputLexemeCategory
+	stackLexemeCategory
+	fileLexemeCategory
+	contextLexemeCategory
+	loopLexemeCategory
+	conditionLexemeCategory
+	procedureLexemeCategory
+	commentLexemeCategory
+	includeLexemeCategory
+	modifierLexemeCategory
+)
+
+type lexemeStackEffect struct {
+	required int
+	popped   int
+	pushed   int
+	cleared  bool
+}
+
+type lexemeMerge struct {
+	previous lexeme
+	r        rune
+}
+
+type lexemeDefinition struct {
+	lexeme      lexeme
+	kind        lexemeKind
+	category    lexemeCategory
+	name        string
+	text        string
+	sectionEnd  lexeme
+	stackEffect lexemeStackEffect
+	examples    []string // used instead of text by literals, which have no fixed text, and by commands that are followed by a name
+	pattern     string   // matches the text of literals and named commands in TextMate grammars
+	vimPattern  string   // matches the text of literals and named commands in vim, using very magic mode
+	description string
+}
+
+var (
+	lexemeDefinitions = []lexemeDefinition{
+		{
+			lexeme:      addOneLexeme,
+			kind:        commandLexemeKind,
+			category:    arithmeticLexemeCategory,
+			name:        "ADD-ONE",
+			text:        "+",
+			description: "Adds `1` to the **current value**.",
+		},
+		{
+			lexeme:      addEightLexeme,
+			kind:        commandLexemeKind,
+			category:    arithmeticLexemeCategory,
+			name:        "ADD-EIGHT",
+			text:        "++",
+			description: "Adds `8` to the **current value**.",
+		},
+		{
+			lexeme:      addStackPairLexeme,
+			kind:        commandLexemeKind,
+			category:    arithmeticLexemeCategory,
+			name:        "ADD-STACK-PAIR",
+			text:        "%+",
+			stackEffect: lexemeStackEffect{required: 2, popped: 2},
+			description: "Pops the two topmost values from the **current stack**, adds one to the other and sets the **current value** to the result.",
+		},
+		{
+			lexeme:      addStackWholeLexeme,
+			kind:        commandLexemeKind,
+			category:    arithmeticLexemeCategory,
+			name:        "ADD-STACK-WHOLE",
+			text:        "%++",
+			stackEffect: lexemeStackEffect{required: 1, cleared: true},
+			description: "Pops all of the values from the **current stack**, adds each of them to the others and sets the **current value** to the result.",
+		},
+		{
+			lexeme:      subtractOneLexeme,
+			kind:        commandLexemeKind,
+			category:    arithmeticLexemeCategory,
+			name:        "SUB-ONE",
+			text:        "-",
+			description: "Subtracts `1` from the **current value**.",
+		},
+		{
+			lexeme:      subtractEightLexeme,
+			kind:        commandLexemeKind,
+			category:    arithmeticLexemeCategory,
+			name:        "SUB-EIGHT",
+			text:        "--",
+			description: "Subtracts `8` from the **current value**.",
+		},
+		{
+			lexeme:      subtractStackPairLexeme,
+			kind:        commandLexemeKind,
+			category:    arithmeticLexemeCategory,
+			name:        "SUB-STACK-PAIR",
+			text:        "%-",
+			stackEffect: lexemeStackEffect{required: 2, popped: 2},
+			description: "Pops the two topmost values from the **current stack**, subtracts one from the other and sets the **current value** to the result.",
+		},
+		{
+			lexeme:      subtractStackWholeLexeme,
+			kind:        commandLexemeKind,
+			category:    arithmeticLexemeCategory,
+			name:        "SUB-STACK-WHOLE",
+			text:        "%--",
+			stackEffect: lexemeStackEffect{required: 1, cleared: true},
+			description: "Pops all of the values from the **current stack**, subtracts each of them from the others and sets the **current value** to the result.",
+		},
+		{
+			lexeme:      divideTwoLexeme,
+			kind:        commandLexemeKind,
+			category:    arithmeticLexemeCategory,
+			name:        "DIV-TWO",
+			text:        "/",
+			description: "Divides the **current value** by `2`.",
+		},
+		{
+			lexeme:      divideEightLexeme,
+			kind:        commandLexemeKind,
+			category:    arithmeticLexemeCategory,
+			name:        "DIV-EIGHT",
+			text:        "//",
+			description: "Divides the **current value** by `8`.",
+		},
+		{
+			lexeme:      divideStackPairLexeme,
+			kind:        commandLexemeKind,
+			category:    arithmeticLexemeCategory,
+			name:        "DIV-STACK-PAIR",
+			text:        "%/",
+			stackEffect: lexemeStackEffect{required: 2, popped: 2},
+			description: "Pops the two topmost values from the **current stack**, divides one from the other and sets the **current value** to the result. Dividing by zero stops the program with an error, unless the `--division-by-zero` flag says otherwise.",
+		},
+		{
+			lexeme:      divideStackWholeLexeme,
+			kind:        commandLexemeKind,
+			category:    arithmeticLexemeCategory,
+			name:        "DIV-STACK-WHOLE",
+			text:        "%//",
+			stackEffect: lexemeStackEffect{required: 1, cleared: true},
+			description: "Pops all of the values from the **current stack**, divides the topmost value by each of the others in turn and sets the **current value** to the result. Dividing by zero stops the program with an error, unless the `--division-by-zero` flag says otherwise.",
+		},
+		{
+			lexeme:      moduloTwoLexeme,
+			kind:        commandLexemeKind,
+			category:    arithmeticLexemeCategory,
+			name:        "MOD-TWO",
+			text:        "m",
+			description: "Sets the **current value** to the remainder of dividing it by `2`.",
+		},
+		{
+			lexeme:      moduloEightLexeme,
+			kind:        commandLexemeKind,
+			category:    arithmeticLexemeCategory,
+			name:        "MOD-EIGHT",
+			text:        "mm",
+			description: "Sets the **current value** to the remainder of dividing it by `8`.",
+		},
+		{
+			lexeme:      moduloStackPairLexeme,
+			kind:        commandLexemeKind,
+			category:    arithmeticLexemeCategory,
+			name:        "MOD-STACK-PAIR",
+			text:        "%m",
+			stackEffect: lexemeStackEffect{required: 2, popped: 2},
+			description: "Pops the two topmost values from the **current stack**, divides one by the other and sets the **current value** to the remainder. Dividing by zero stops the program with an error, unless the `--division-by-zero` flag says otherwise.",
+		},
+		{
+			lexeme:      moduloStackWholeLexeme,
+			kind:        commandLexemeKind,
+			category:    arithmeticLexemeCategory,
+			name:        "MOD-STACK-WHOLE",
+			text:        "%mm",
+			stackEffect: lexemeStackEffect{required: 1, cleared: true},
+			description: "Pops all of the values from the **current stack**, divides the topmost value by each of the others in turn, keeping only the remainder each time, and sets the **current value** to the result. Dividing by zero stops the program with an error, unless the `--division-by-zero` flag says otherwise.",
+		},
+		{
+			lexeme:      multiplyTwoLexeme,
+			kind:        commandLexemeKind,
+			category:    arithmeticLexemeCategory,
+			name:        "MULT-TWO",
+			text:        "*",
+			description: "Multiplies the **current value** by `2`.",
+		},
+		{
+			lexeme:      multiplyEightLexeme,
+			kind:        commandLexemeKind,
+			category:    arithmeticLexemeCategory,
+			name:        "MULT-EIGHT",
+			text:        "**",
+			description: "Multiplies the **current value** by `8`.",
+		},
+		{
+			lexeme:      multiplyStackPairLexeme,
+			kind:        commandLexemeKind,
+			category:    arithmeticLexemeCategory,
+			name:        "MULT-STACK-PAIR",
+			text:        "%*",
+			stackEffect: lexemeStackEffect{required: 2, popped: 2},
+			description: "Pops the two topmost values from the **current stack**, multiplies one with the other and sets the **current value** to the result.",
+		},
+		{
+			lexeme:      multiplyStackWholeLexeme,
+			kind:        commandLexemeKind,
+			category:    arithmeticLexemeCategory,
+			name:        "MULT-STACK-WHOLE",
+			text:        "%**",
+			stackEffect: lexemeStackEffect{required: 1, cleared: true},
+			description: "Pops all of the values from the **current stack**, multiplies each of them with the others and sets the **current value** to the result.",
+		},
+		{
+			lexeme:      squareLexeme,
+			kind:        commandLexemeKind,
+			category:    arithmeticLexemeCategory,
+			name:        "SQUARE",
+			text:        "^",
+			description: "Squares the **current value** (i.e. multiplies it by itself).",
+		},
+		{
+			lexeme:      cubeLexeme,
+			kind:        commandLexemeKind,
+			category:    arithmeticLexemeCategory,
+			name:        "CUBE",
+			text:        "^^",
+			description: "Cubes the **current value** (i.e. multiplies it by itself twice).",
+		},
+		{
+			lexeme:      bitwiseAndStackPairLexeme,
+			kind:        commandLexemeKind,
+			category:    bitwiseLexemeCategory,
+			name:        "BIT-AND-STACK-PAIR",
+			text:        "%a",
+			stackEffect: lexemeStackEffect{required: 2, popped: 2},
+			description: "Pops the two topmost values from the **current stack**, performs a bitwise AND operation on them and sets the **current value** to the result.",
+		},
+		{
+			lexeme:      bitwiseAndStackWholeLexeme,
+			kind:        commandLexemeKind,
+			category:    bitwiseLexemeCategory,
+			name:        "BIT-AND-STACK-WHOLE",
+			text:        "%aa",
+			stackEffect: lexemeStackEffect{required: 1, cleared: true},
+			description: "Pops all of the values from the **current stack**, performs a bitwise AND operation on them and sets the **current value** to the result.",
+		},
+		{
+			lexeme:      bitwiseOrStackPairLexeme,
+			kind:        commandLexemeKind,
+			category:    bitwiseLexemeCategory,
+			name:        "BIT-OR-STACK-PAIR",
+			text:        "%o",
+			stackEffect: lexemeStackEffect{required: 2, popped: 2},
+			description: "Pops the two topmost values from the **current stack**, performs a bitwise OR operation on them and sets the **current value** to the result.",
+		},
+		{
+			lexeme:      bitwiseOrStackWholeLexeme,
+			kind:        commandLexemeKind,
+			category:    bitwiseLexemeCategory,
+			name:        "BIT-OR-STACK-WHOLE",
+			text:        "%oo",
+			stackEffect: lexemeStackEffect{required: 1, cleared: true},
+			description: "Pops all of the values from the **current stack**, performs a bitwise OR operation on them and sets the **current value** to the result.",
+		},
+		{
+			lexeme:      bitwiseXorStackPairLexeme,
+			kind:        commandLexemeKind,
+			category:    bitwiseLexemeCategory,
+			name:        "BIT-XOR-STACK-PAIR",
+			text:        "%x",
+			stackEffect: lexemeStackEffect{required: 2, popped: 2},
+			description: "Pops the two topmost values from the **current stack**, performs a bitwise XOR operation on them and sets the **current value** to the result.",
+		},
+		{
+			lexeme:      bitwiseXorStackWholeLexeme,
+			kind:        commandLexemeKind,
+			category:    bitwiseLexemeCategory,
+			name:        "BIT-XOR-STACK-WHOLE",
+			text:        "%xx",
+			stackEffect: lexemeStackEffect{required: 1, cleared: true},
+			description: "Pops all of the values from the **current stack**, performs a bitwise XOR operation on them and sets the **current value** to the result.",
+		},
+		{
+			lexeme:      bitwiseNotLexeme,
+			kind:        commandLexemeKind,
+			category:    bitwiseLexemeCategory,
+			name:        "BIT-NOT",
+			text:        "n",
+			description: "Flips every bit of the **current value** (i.e. performs a bitwise NOT operation on it).",
+		},
+		{
+			lexeme:      bitCountLexeme,
+			kind:        commandLexemeKind,
+			category:    bitwiseLexemeCategory,
+			name:        "BIT-COUNT",
+			text:        "b",
+			description: "Sets the **current value** to the number of its bits that are set to `1` (i.e. its population count).",
+		},
+		{
+			lexeme:      shiftLeftOneLexeme,
+			kind:        commandLexemeKind,
+			category:    bitwiseLexemeCategory,
+			name:        "SHIFT-LEFT-ONE",
+			text:        "h",
+			description: "Shifts the bits of the **current value** left by `1`.",
+		},
+		{
+			lexeme:      shiftLeftEightLexeme,
+			kind:        commandLexemeKind,
+			category:    bitwiseLexemeCategory,
+			name:        "SHIFT-LEFT-EIGHT",
+			text:        "hh",
+			description: "Shifts the bits of the **current value** left by `8`.",
+		},
+		{
+			lexeme:      shiftLeftStackLexeme,
+			kind:        commandLexemeKind,
+			category:    bitwiseLexemeCategory,
+			name:        "SHIFT-LEFT-STACK",
+			text:        "%h",
+			stackEffect: lexemeStackEffect{required: 1, popped: 1},
+			description: "Pops a value from the end of the **current stack** and shifts the bits of the **current value** left by that amount.",
+		},
+		{
+			lexeme:      shiftRightOneLexeme,
+			kind:        commandLexemeKind,
+			category:    bitwiseLexemeCategory,
+			name:        "SHIFT-RIGHT-ONE",
+			text:        "l",
+			description: "Shifts the bits of the **current value** right by `1`.",
+		},
+		{
+			lexeme:      shiftRightEightLexeme,
+			kind:        commandLexemeKind,
+			category:    bitwiseLexemeCategory,
+			name:        "SHIFT-RIGHT-EIGHT",
+			text:        "ll",
+			description: "Shifts the bits of the **current value** right by `8`.",
+		},
+		{
+			lexeme:      shiftRightStackLexeme,
+			kind:        commandLexemeKind,
+			category:    bitwiseLexemeCategory,
+			name:        "SHIFT-RIGHT-STACK",
+			text:        "%l",
+			stackEffect: lexemeStackEffect{required: 1, popped: 1},
+			description: "Pops a value from the end of the **current stack** and shifts the bits of the **current value** right by that amount.",
+		},
+		{
+			lexeme:      printCharacterLexeme,
+			kind:        commandLexemeKind,
+			category:    inputOutputLexemeCategory,
+			name:        "PRINT-CHAR",
+			text:        "!",
+			description: "Prints the **current value** to the screen as a Unicode/ASCII character.",
+		},
+		{
+			lexeme:      printNumberLexeme,
+			kind:        commandLexemeKind,
+			category:    inputOutputLexemeCategory,
+			name:        "PRINT-NUM",
+			text:        "!!",
+			description: "Prints the **current value** to the screen as a decimal number.",
+		},
+		{
+			lexeme:      inputCharacterLexeme,
+			kind:        commandLexemeKind,
+			category:    inputOutputLexemeCategory,
+			name:        "INPUT-CHAR",
+			text:        "?",
+			description: "Waits for a Unicode/ASCII character to be given as input, then sets the **current value** to its numerical value.",
+		},
+		{
+			lexeme:      inputNumberLexeme,
+			kind:        commandLexemeKind,
+			category:    inputOutputLexemeCategory,
+			name:        "INPUT-NUM",
+			text:        "??",
+			description: "Waits for a decimal number to be given as input, then sets the **current value** to it.",
+		},
+		{
+			lexeme:      setZeroLexeme,
+			kind:        commandLexemeKind,
+			category:    valueLexemeCategory,
+			name:        "SET-ZERO",
+			text:        "~",
+			description: "Sets the **current value** to `0`.",
+		},
+		{
+			lexeme:      setOneByteLexeme,
+			kind:        commandLexemeKind,
+			category:    valueLexemeCategory,
+			name:        "SET-ONE-BYTE",
+			text:        "'",
+			description: "Sets the **current value** to the size of a byte (i.e. `8`).",
+		},
+		{
+			lexeme:      setEightByteLexeme,
+			kind:        commandLexemeKind,
+			category:    valueLexemeCategory,
+			name:        "SET-EIGHT-BYTE",
+			text:        "''",
+			description: "Sets the **current value** to the size of eight bytes (i.e. `64`).",
+		},
+		{
+			lexeme:      setOneKibibyteLexeme,
+			kind:        commandLexemeKind,
+			category:    valueLexemeCategory,
+			name:        "SET-ONE-KIBI",
+			text:        "\"",
+			description: "Sets the **current value** to the size of a kibibyte (i.e. `8_192`).",
+		},
+		{
+			lexeme:      setEightKibibyteLexeme,
+			kind:        commandLexemeKind,
+			category:    valueLexemeCategory,
+			name:        "SET-EIGHT-KIBI",
+			text:        "\"\"",
+			description: "Sets the **current value** to the size of eight kibibytes (i.e. `65_536`).",
+		},
+		{
+			lexeme:      setOneMebibyteLexeme,
+			kind:        commandLexemeKind,
+			category:    valueLexemeCategory,
+			name:        "SET-ONE-MEBI",
+			text:        "%'",
+			description: "Sets the **current value** to the size of a mebibyte (i.e. `8_388_608`).",
+		},
+		{
+			lexeme:      setEightMebibyteLexeme,
+			kind:        commandLexemeKind,
+			category:    valueLexemeCategory,
+			name:        "SET-EIGHT-MEBI",
+			text:        "%''",
+			description: "Sets the **current value** to the size of eight mebibytes (i.e. `67_108_864`).",
+		},
+		{
+			lexeme:      setOneGibibyteLexeme,
+			kind:        commandLexemeKind,
+			category:    valueLexemeCategory,
+			name:        "SET-ONE-GIBI",
+			text:        "%\"",
+			description: "Sets the **current value** to the size of a gibibyte (i.e. `8_589_934_592`).",
+		},
+		{
+			lexeme:      setEightGibibyteLexeme,
+			kind:        commandLexemeKind,
+			category:    valueLexemeCategory,
+			name:        "SET-EIGHT-GIBI",
+			text:        "%\"\"",
+			description: "Sets the **current value** to the size of eight gibibytes (i.e. `68_719_476_736`).",
+		},
+		{
+			lexeme:      setLiteralLexeme,
+			kind:        literalLexemeKind,
+			category:    valueLexemeCategory,
+			name:        "SET-LITERAL",
+			examples:    []string{"26", "0x1a"},
+			pattern:     "0[xX][0-9A-Fa-f]*|[0-9]+",
+			vimPattern:  `0[xX]\x*|\d+`,
+			description: "Sets the **current value** to a decimal number, or to a hexadecimal number if it begins with `0x`. The number must fit into an unsigned 64-bit integer.",
+		},
+		{
+			lexeme:      setOverflowModeLexeme,
+			kind:        commandLexemeKind,
+			category:    arithmeticLexemeCategory,
+			name:        "SET-OVERFLOW",
+			text:        "%#",
+			examples:    []string{"%#wrap", "%#saturate", "%#trap"},
+			pattern:     `%#[A-Za-z0-9_]*`,
+			vimPattern:  `\%#\w*`,
+			description: "Sets how `+`, `++`, `-`, `--`, `*`, `**`, `^`, `^^`, `%+`, `%++`, `%-`, `%--`, `%*`, `%**` and the `(` ... `)`, `((` ... `))` and `[` ... `]` sections behave when their result does not fit into the **current value**, for the rest of the section that holds it (or the rest of the file, outside any section). The mode is written directly after `%#`: `wrap` lets the result roll over (the default), `saturate` stops it at `0` or the maximum value, and `trap` stops the program with an error. The starting mode can be set with the `--overflow` flag.",
+		},
+		{
+			lexeme:      setRandomByteLexeme,
+			kind:        commandLexemeKind,
+			category:    valueLexemeCategory,
+			name:        "SET-RAND-BYTE",
+			text:        "`",
+			description: "Sets the **current value** to a random number between `0` and `255`.",
+		},
+		{
+			lexeme:      setRandomMaxLexeme,
+			kind:        commandLexemeKind,
+			category:    valueLexemeCategory,
+			name:        "SET-RAND-MAX",
+			text:        "``",
+			description: "Sets the **current value** to a random number between `0` and the maximum value for an unsigned 64-bit integer.",
+		},
+		{
+			lexeme:      setSecondTimestampLexeme,
+			kind:        commandLexemeKind,
+			category:    valueLexemeCategory,
+			name:        "SET-SEC-TIME",
+			text:        "@",
+			description: "Sets the **current value** to the number of seconds in a UNIX-timestamp representation of the current time.",
+		},
+		{
+			lexeme:      setNanosecondTimestampLexeme,
+			kind:        commandLexemeKind,
+			category:    valueLexemeCategory,
+			name:        "SET-NANO-TIME",
+			text:        "@@",
+			description: "Sets the **current value** to the number of nanoseconds in a UNIX-timestamp representation of the current time.",
+		},
+		{
+			lexeme:      logicalAndStackPairLexeme,
+			kind:        commandLexemeKind,
+			category:    logicLexemeCategory,
+			name:        "LOGIC-AND-STACK-PAIR",
+			text:        "%&",
+			stackEffect: lexemeStackEffect{required: 2},
+			description: "Performs a logical AND operation on the two topmost values in the **current stack**, setting the **current value** to `1` if both values from the stack do not equal `0`, otherwise setting the **current value** to `0`. No values are popped from the **current stack**.",
+		},
+		{
+			lexeme:      logicalAndStackWholeLexeme,
+			kind:        commandLexemeKind,
+			category:    logicLexemeCategory,
+			name:        "LOGIC-AND-STACK-WHOLE",
+			text:        "%&&",
+			stackEffect: lexemeStackEffect{required: 1},
+			description: "Performs a logical AND operation on all of the values in the **current stack**, setting the **current value** to `1` if all values from the stack do not equal `0`, otherwise setting the **current value** to `0`. No values are popped from the **current stack**.",
+		},
+		{
+			lexeme:      logicalOrStackPairLexeme,
+			kind:        commandLexemeKind,
+			category:    logicLexemeCategory,
+			name:        "LOGIC-OR-STACK-PAIR",
+			text:        "%v",
+			stackEffect: lexemeStackEffect{required: 2},
+			description: "Performs a logical OR operation on the two topmost values in the **current stack**, setting the **current value** to `1` if either value from the stack does not equal `0`, otherwise setting the **current value** to `0`. No values are popped from the **current stack**.",
+		},
+		{
+			lexeme:      logicalOrStackWholeLexeme,
+			kind:        commandLexemeKind,
+			category:    logicLexemeCategory,
+			name:        "LOGIC-OR-STACK-WHOLE",
+			text:        "%vv",
+			stackEffect: lexemeStackEffect{required: 1},
+			description: "Performs a logical OR operation on all of the values in the **current stack**, setting the **current value** to `1` if any value from the stack does not equal `0`, otherwise setting the **current value** to `0`. No values are popped from the **current stack**.",
+		},
+		{
+			lexeme:      logicalXorStackPairLexeme,
+			kind:        commandLexemeKind,
+			category:    logicLexemeCategory,
+			name:        "LOGIC-XOR-STACK-PAIR",
+			text:        "%^",
+			stackEffect: lexemeStackEffect{required: 2},
+			description: "Performs a logical XOR operation on the two topmost values in the **current stack**, setting the **current value** to `1` if exactly one value from the stack does not equal `0`, otherwise setting the **current value** to `0`. No values are popped from the **current stack**.",
+		},
+		{
+			lexeme:      logicalXorStackWholeLexeme,
+			kind:        commandLexemeKind,
+			category:    logicLexemeCategory,
+			name:        "LOGIC-XOR-STACK-WHOLE",
+			text:        "%^^",
+			stackEffect: lexemeStackEffect{required: 1},
+			description: "Performs a logical XOR operation on all of the values in the **current stack**, setting the **current value** to `1` if an odd number of values from the stack do not equal `0`, otherwise setting the **current value** to `0`. No values are popped from the **current stack**.",
+		},
+		{
+			lexeme:      equalStackPairLexeme,
+			kind:        commandLexemeKind,
+			category:    logicLexemeCategory,
+			name:        "CMP-EQ-STACK-PAIR",
+			text:        "%=",
+			stackEffect: lexemeStackEffect{required: 2},
+			description: "Compares the two topmost values in the **current stack**, setting the **current value** to `1` if the topmost value equals the value below it, otherwise setting the **current value** to `0`. No values are popped from the **current stack**.",
+		},
+		{
+			lexeme:      notEqualStackPairLexeme,
+			kind:        commandLexemeKind,
+			category:    logicLexemeCategory,
+			name:        "CMP-NOT-EQ-STACK-PAIR",
+			text:        "%<>",
+			stackEffect: lexemeStackEffect{required: 2},
+			description: "Compares the two topmost values in the **current stack**, setting the **current value** to `1` if the topmost value does not equal the value below it, otherwise setting the **current value** to `0`. No values are popped from the **current stack**.",
+		},
+		{
+			lexeme:      lessStackPairLexeme,
+			kind:        commandLexemeKind,
+			category:    logicLexemeCategory,
+			name:        "CMP-LESS-STACK-PAIR",
+			text:        "%<",
+			stackEffect: lexemeStackEffect{required: 2},
+			description: "Compares the two topmost values in the **current stack**, setting the **current value** to `1` if the topmost value is less than the value below it, otherwise setting the **current value** to `0`. No values are popped from the **current stack**.",
+		},
+		{
+			lexeme:      greaterStackPairLexeme,
+			kind:        commandLexemeKind,
+			category:    logicLexemeCategory,
+			name:        "CMP-GREATER-STACK-PAIR",
+			text:        "%>",
+			stackEffect: lexemeStackEffect{required: 2},
+			description: "Compares the two topmost values in the **current stack**, setting the **current value** to `1` if the topmost value is greater than the value below it, otherwise setting the **current value** to `0`. No values are popped from the **current stack**.",
+		},
+		{
+			lexeme:      lessOrEqualStackPairLexeme,
+			kind:        commandLexemeKind,
+			category:    logicLexemeCategory,
+			name:        "CMP-LESS-EQ-STACK-PAIR",
+			text:        "%<=",
+			stackEffect: lexemeStackEffect{required: 2},
+			description: "Compares the two topmost values in the **current stack**, setting the **current value** to `1` if the topmost value is less than or equal to the value below it, otherwise setting the **current value** to `0`. No values are popped from the **current stack**.",
+		},
+		{
+			lexeme:      greaterOrEqualStackPairLexeme,
+			kind:        commandLexemeKind,
+			category:    logicLexemeCategory,
+			name:        "CMP-GREATER-EQ-STACK-PAIR",
+			text:        "%>=",
+			stackEffect: lexemeStackEffect{required: 2},
+			description: "Compares the two topmost values in the **current stack**, setting the **current value** to `1` if the topmost value is greater than or equal to the value below it, otherwise setting the **current value** to `0`. No values are popped from the **current stack**.",
+		},
+		{
+			lexeme:      invertLexeme,
+			kind:        commandLexemeKind,
+			category:    logicLexemeCategory,
+			name:        "INVERT",
+			text:        "\\",
+			description: "Inverts the **current value** as though it were a boolean (i.e. sets the **current value** to `0` if it is not already `0`, otherwise sets it to `1`).",
+		},
+		{
+			lexeme:      useStackIndexZeroLexeme,
+			kind:        commandLexemeKind,
+			category:    stackLexemeCategory,
+			name:        "USE-STACK-ZERO",
+			text:        "$",
+			description: "Uses the first of two stacks when calling further commands that make use of a stack.",
+		},
+		{
+			lexeme:      useStackIndexOneLexeme,
+			kind:        commandLexemeKind,
+			category:    stackLexemeCategory,
+			name:        "USE-STACK-ONE",
+			text:        "$$",
+			description: "Uses the second of two stacks when calling further commands that make use of a stack.",
+		},
+		{
+			lexeme:      useStackIndexSwappedLexeme,
+			kind:        commandLexemeKind,
+			category:    stackLexemeCategory,
+			name:        "USE-STACK-SWAPPED",
+			text:        "%$",
+			description: "Uses the currently unused one of two stacks when calling further commands that make use of a stack.",
+		},
+		{
+			lexeme:      pushStackLexeme,
+			kind:        commandLexemeKind,
+			category:    stackLexemeCategory,
+			name:        "PUSH-STACK",
+			text:        ":",
+			stackEffect: lexemeStackEffect{pushed: 1},
+			description: "Pushes the **current value** to the end of the **current stack**.",
+		},
+		{
+			lexeme:      pushStringLexeme,
+			kind:        literalLexemeKind,
+			category:    stackLexemeCategory,
+			name:        "PUSH-STRING",
+			examples:    []string{`%{Hello, world!\n}`},
+			pattern:     `%\{(?:\\u\{[^}]*\}|\\.|[^\\}])*\}`,
+			vimPattern:  `\%\{(\\u\{[^}]*\}|\\.|[^\\}])*\}`,
+			description: "Pushes the Unicode/ASCII characters between the braces onto the **current stack** in reverse order, so that they are popped in the order in which they are written, in the same way as the contents of a file read with `{{` ... `}}`. The escape sequences `\\\\`, `\\}`, `\\n`, `\\r`, `\\t`, `\\0`, `\\xFF` and `\\u{10FFFF}` can be used within the braces.",
+		},
+		{
+			lexeme:      countStackLexeme,
+			kind:        commandLexemeKind,
+			category:    stackLexemeCategory,
+			name:        "COUNT-STACK",
+			text:        "%:",
+			description: "Sets the **current value** to the number of values stored in the **current stack**.",
+		},
+		{
+			lexeme:      popStackLastLexeme,
+			kind:        commandLexemeKind,
+			category:    stackLexemeCategory,
+			name:        "POP-STACK-LAST",
+			text:        ";",
+			stackEffect: lexemeStackEffect{required: 1, popped: 1},
+			description: "Sets the **current value** to a value popped from the end of the **current stack**.",
+		},
+		{
+			lexeme:      popStackRandomLexeme,
+			kind:        commandLexemeKind,
+			category:    stackLexemeCategory,
+			name:        "POP-STACK-RAND",
+			text:        "%;",
+			stackEffect: lexemeStackEffect{required: 1, popped: 1},
+			description: "Sets the **current value** to a value popped from a random position in the **current stack**.",
+		},
+		{
+			lexeme:      hashStackOneByteLexeme,
+			kind:        commandLexemeKind,
+			category:    stackLexemeCategory,
+			name:        "HASH-STACK-ONE-BYTE",
+			text:        "#",
+			description: "Pops all the values from the **current stack**, performs an 8-bit hash on them and sets the **current value** to the result.",
+		},
+		{
+			lexeme:      hashStackEightByteLexeme,
+			kind:        commandLexemeKind,
+			category:    stackLexemeCategory,
+			name:        "HASH-STACK-EIGHT-BYTE",
+			text:        "##",
+			description: "Pops all the values from the **current stack**, performs a 64-bit hash on them and sets the **current value** to the result.",
+		},
+		{
+			lexeme:      sortStackAscendingLexeme,
+			kind:        commandLexemeKind,
+			category:    stackLexemeCategory,
+			name:        "SORT-STACK-ASC",
+			text:        "s",
+			description: "Sorts the **current stack** in ascending order, so that the largest values are at the top and the smallest values are at the bottom.",
+		},
+		{
+			lexeme:      sortStackDescendingLexeme,
+			kind:        commandLexemeKind,
+			category:    stackLexemeCategory,
+			name:        "SORT-STACK-DESC",
+			text:        "ss",
+			description: "Sorts the **current stack** in descending order, so that the largest values are at the bottom and the smallest values are at the top.",
+		},
+		{
+			lexeme:      shuffleStackLexeme,
+			kind:        commandLexemeKind,
+			category:    stackLexemeCategory,
+			name:        "SHUFFLE-STACK",
+			text:        "%s",
+			description: "Shuffles the **current stack** so that the values are in a random order.",
+		},
+		{
+			lexeme:      swapStackTopLexeme,
+			kind:        commandLexemeKind,
+			category:    stackLexemeCategory,
+			name:        "SWAP-STACK-TOP",
+			text:        "x",
+			stackEffect: lexemeStackEffect{required: 2},
+			description: "Swaps the top two values on the **current stack**, so that the topmost becomes the second-to-topmost (and *vice versa*).",
+		},
+		{
+			lexeme:      reverseStackLexeme,
+			kind:        commandLexemeKind,
+			category:    stackLexemeCategory,
+			name:        "REVERSE-STACK",
+			text:        "r",
+			description: "Reverses the order of all values in the **current stack**.",
+		},
+		{
+			lexeme:      duplicateStackTopLexeme,
+			kind:        commandLexemeKind,
+			category:    stackLexemeCategory,
+			name:        "DUP-STACK-TOP",
+			text:        "d",
+			stackEffect: lexemeStackEffect{required: 1, pushed: 1},
+			description: "Pushes a copy of the topmost value in the **current stack** onto the **current stack**.",
+		},
+		{
+			lexeme:      dropStackTopLexeme,
+			kind:        commandLexemeKind,
+			category:    stackLexemeCategory,
+			name:        "DROP-STACK-TOP",
+			text:        "z",
+			stackEffect: lexemeStackEffect{required: 1, popped: 1},
+			description: "Pops the topmost value from the **current stack** and discards it, leaving the **current value** unchanged.",
+		},
+		{
+			lexeme:      overStackLexeme,
+			kind:        commandLexemeKind,
+			category:    stackLexemeCategory,
+			name:        "OVER-STACK",
+			text:        "o",
+			stackEffect: lexemeStackEffect{required: 2, pushed: 1},
+			description: "Pushes a copy of the second-to-topmost value in the **current stack** onto the **current stack**.",
+		},
+		{
+			lexeme:      rotateStackThreeLexeme,
+			kind:        commandLexemeKind,
+			category:    stackLexemeCategory,
+			name:        "ROT-STACK-THREE",
+			text:        "t",
+			stackEffect: lexemeStackEffect{required: 3},
+			description: "Rotates the three topmost values in the **current stack**, so that the third-to-topmost value becomes the topmost.",
+		},
+		{
+			lexeme:      peekStackTopLexeme,
+			kind:        commandLexemeKind,
+			category:    stackLexemeCategory,
+			name:        "PEEK-STACK-TOP",
+			text:        "p",
+			stackEffect: lexemeStackEffect{required: 1},
+			description: "Sets the **current value** to the topmost value in the **current stack** without popping it.",
+		},
+		{
+			lexeme:      pickStackLexeme,
+			kind:        commandLexemeKind,
+			category:    stackLexemeCategory,
+			name:        "PICK-STACK",
+			text:        "%d",
+			stackEffect: lexemeStackEffect{required: 1, pushed: 1},
+			description: "Pushes a copy of a value in the **current stack** onto the **current stack**, where the **current value** gives the position of the value counting down from the top (so `0` copies the topmost value, like `d`, and `1` copies the second-to-topmost value, like `o`).",
+		},
+		{
+			lexeme:      rollStackLexeme,
+			kind:        commandLexemeKind,
+			category:    stackLexemeCategory,
+			name:        "ROLL-STACK",
+			text:        "%t",
+			stackEffect: lexemeStackEffect{required: 1},
+			description: "Moves a value in the **current stack** to the top of the **current stack**, where the **current value** gives the position of the value counting down from the top (so `1` swaps the two topmost values, like `x`, and `2` rotates the three topmost values, like `t`).",
+		},
+		{
+			lexeme:      giveStackTopLexeme,
+			kind:        commandLexemeKind,
+			category:    stackLexemeCategory,
+			name:        "GIVE-STACK-TOP",
+			text:        "g",
+			stackEffect: lexemeStackEffect{required: 1, popped: 1},
+			description: "Pops the topmost value from the **current stack** and pushes it onto the other stack, without changing which stack is the **current stack**.",
+		},
+		{
+			lexeme:      giveStackManyLexeme,
+			kind:        commandLexemeKind,
+			category:    stackLexemeCategory,
+			name:        "GIVE-STACK-MANY",
+			text:        "%g",
+			description: "Moves a number of values from the top of the **current stack** onto the other stack, keeping them in the same order, where the number of values is given by the **current value**. The **current stack** remains the same.",
+		},
+		{
+			lexeme:      giveStackWholeLexeme,
+			kind:        commandLexemeKind,
+			category:    stackLexemeCategory,
+			name:        "GIVE-STACK-WHOLE",
+			text:        "gg",
+			stackEffect: lexemeStackEffect{cleared: true},
+			description: "Moves all of the values in the **current stack** onto the top of the other stack, keeping them in the same order. The **current stack** remains the same.",
+		},
+		{
+			lexeme:      callProcedureLexeme,
+			kind:        commandLexemeKind,
+			category:    procedureLexemeCategory,
+			name:        "CALL-PROC",
+			text:        "&",
+			examples:    []string{"&name"},
+			pattern:     `&[A-Za-z0-9_]+`,
+			vimPattern:  `\&\w+`,
+			description: "Calls the procedure with the name written directly after `&` (e.g. `&fib` or `&7`), running the commands in its body with the same **current value** and stacks. A `&` that is not followed by a name is ignored. A procedure can call itself, but procedures can only be called up to `1024` levels deep, which can be changed with the `--max-call-depth` flag.",
+		},
+		{
+			lexeme:      breakLoopLexeme,
+			kind:        commandLexemeKind,
+			category:    loopLexemeCategory,
+			name:        "BREAK-LOOP",
+			text:        "%b",
+			description: "Leaves the innermost `<` ... `>` or `<<` ... `>>` loop that surrounds it straight away, without running the rest of its commands or checking the **current value** again. Any context sections between the command and the loop are left without changing the **current value** of their surrounding context. It can only be used inside a loop in the same file and procedure.",
+		},
+		{
+			lexeme:      continueLoopLexeme,
+			kind:        commandLexemeKind,
+			category:    loopLexemeCategory,
+			name:        "CONT-LOOP",
+			text:        "%c",
+			description: "Skips the rest of the commands in the innermost `<` ... `>` or `<<` ... `>>` loop that surrounds it, then checks the **current value** to decide whether to run the loop again. Any context sections between the command and the loop are left without changing the **current value** of their surrounding context. It can only be used inside a loop in the same file and procedure.",
+		},
+		{
+			lexeme:      iotaFromZeroLexeme,
+			kind:        commandLexemeKind,
+			category:    stackLexemeCategory,
+			name:        "IOTA-ZERO",
+			text:        "i",
+			description: "Pushes an iota-range of values to the **current stack**, from `0` inclusive to the **current value** exclusive.",
+		},
+		{
+			lexeme:      iotaFromOneLexeme,
+			kind:        commandLexemeKind,
+			category:    stackLexemeCategory,
+			name:        "IOTA-ONE",
+			text:        "ii",
+			description: "Pushes an iota-range of values to the **current stack**, from `1` inclusive to the **current value** exclusive.",
+		},
+		{
+			lexeme:      writeStackToFileLexeme,
+			kind:        commandLexemeKind,
+			category:    fileLexemeCategory,
+			name:        "WRITE-STACK-FILE",
+			text:        ".",
+			description: "Saves the **current stack** to a file, using the Unicode/ASCII representation of each value on the stack. The filename is based on the **current value**.",
+		},
+		{
+			lexeme:      readStackFromFileLexeme,
+			kind:        commandLexemeKind,
+			category:    fileLexemeCategory,
+			name:        "READ-STACK-FILE",
+			text:        ",",
+			description: "Loads the **current stack** from a file, using the Unicode/ASCII representation of each value on the stack. The filename is based on the **current value**.",
+		},
+		{
+			lexeme:      deleteFileLexeme,
+			kind:        commandLexemeKind,
+			category:    fileLexemeCategory,
+			name:        "DELETE-STACK-FILE",
+			text:        "|",
+			description: "Deletes a file representing a saved stack. The filename is based on the **current value**.",
+		},
+		{
+			lexeme:      clearStackLexeme,
+			kind:        commandLexemeKind,
+			category:    stackLexemeCategory,
+			name:        "CLEAR-STACK",
+			text:        "||",
+			stackEffect: lexemeStackEffect{cleared: true},
+			description: "Clears the **current stack**.",
+		},
+		{
+			lexeme:      resetStateLexeme,
+			kind:        commandLexemeKind,
+			category:    stackLexemeCategory,
+			name:        "RESET-STATE",
+			text:        "%|",
+			description: "Resets all state (i.e. clears both of the stacks and sets the **current value** to `0`).",
+		},
+		{
+			lexeme:      separatorLexeme,
+			kind:        internalLexemeKind,
+			name:        "SEP",
+			text:        " ",
+			description: "Whitespace can be used to separate two single-character commands that could otherwise be interpreted as a multi-character command.",
+		},
+		{
+			lexeme:      startAdditionSectionLexeme,
+			kind:        sectionStartLexemeKind,
+			category:    contextLexemeCategory,
+			name:        "START-ADD-SECT",
+			text:        "(",
+			sectionEnd:  endAdditionSectionLexeme,
+			description: "Creates a new context with a new **current value** of zero, in which any commands between the brackets are called, then adds the **current value** of the created context to the **current value** of the surrounding context.",
+		},
+		{
+			lexeme:      endAdditionSectionLexeme,
+			kind:        sectionEndLexemeKind,
+			category:    contextLexemeCategory,
+			name:        "END-ADD-SECT",
+			text:        ")",
+			description: "Creates a new context with a new **current value** of zero, in which any commands between the brackets are called, then adds the **current value** of the created context to the **current value** of the surrounding context.",
+		},
+		{
+			lexeme:      startMultiplicationSectionLexeme,
+			kind:        sectionStartLexemeKind,
+			category:    contextLexemeCategory,
+			name:        "START-MULT-SECT",
+			text:        "((",
+			sectionEnd:  endMultiplicationSectionLexeme,
+			description: "Creates a new context with a new **current value** of zero, in which any commands between the brackets are called, then multiplies the **current value** of the created context by the **current value** of the surrounding context.",
+		},
+		{
+			lexeme:      endMultiplicationSectionLexeme,
+			kind:        sectionEndLexemeKind,
+			category:    contextLexemeCategory,
+			name:        "END-MULT-SECT",
+			text:        "))",
+			description: "Creates a new context with a new **current value** of zero, in which any commands between the brackets are called, then multiplies the **current value** of the created context by the **current value** of the surrounding context.",
+		},
+		{
+			lexeme:      startSubtractionSectionLexeme,
+			kind:        sectionStartLexemeKind,
+			category:    contextLexemeCategory,
+			name:        "START-SUB-SECT",
+			text:        "[",
+			sectionEnd:  endSubtractionSectionLexeme,
+			description: "Creates a new context with a new **current value** of zero, in which any commands between the brackets are called, then subtracts the **current value** of the created context from the **current value** of the surrounding context.",
+		},
+		{
+			lexeme:      endSubtractionSectionLexeme,
+			kind:        sectionEndLexemeKind,
+			category:    contextLexemeCategory,
+			name:        "END-SUB-SECT",
+			text:        "]",
+			description: "Creates a new context with a new **current value** of zero, in which any commands between the brackets are called, then subtracts the **current value** of the created context from the **current value** of the surrounding context.",
+		},
+		{
+			lexeme:      startDivisionSectionLexeme,
+			kind:        sectionStartLexemeKind,
+			category:    contextLexemeCategory,
+			name:        "START-DIV-SECT",
+			text:        "[[",
+			sectionEnd:  endDivisionSectionLexeme,
+			description: "Creates a new context with a new **current value** of zero, in which any commands between the brackets are called, then divides the **current value** of the surrounding context by the **current value** of the created context. Dividing by zero stops the program with an error, unless the `--division-by-zero` flag says otherwise.",
+		},
+		{
+			lexeme:      endDivisionSectionLexeme,
+			kind:        sectionEndLexemeKind,
+			category:    contextLexemeCategory,
+			name:        "END-DIV-SECT",
+			text:        "]]",
+			description: "Creates a new context with a new **current value** of zero, in which any commands between the brackets are called, then divides the **current value** of the surrounding context by the **current value** of the created context. Dividing by zero stops the program with an error, unless the `--division-by-zero` flag says otherwise.",
+		},
+		{
+			lexeme:      startModuloSectionLexeme,
+			kind:        sectionStartLexemeKind,
+			category:    contextLexemeCategory,
+			name:        "START-MOD-SECT",
+			text:        "%[",
+			sectionEnd:  endModuloSectionLexeme,
+			description: "Creates a new context with a new **current value** of zero, in which any commands between the brackets are called, then sets the **current value** of the surrounding context to the remainder of dividing it by the **current value** of the created context. Dividing by zero stops the program with an error, unless the `--division-by-zero` flag says otherwise.",
+		},
+		{
+			lexeme:      endModuloSectionLexeme,
+			kind:        sectionEndLexemeKind,
+			category:    contextLexemeCategory,
+			name:        "END-MOD-SECT",
+			text:        "%]",
+			description: "Creates a new context with a new **current value** of zero, in which any commands between the brackets are called, then sets the **current value** of the surrounding context to the remainder of dividing it by the **current value** of the created context. Dividing by zero stops the program with an error, unless the `--division-by-zero` flag says otherwise.",
+		},
+		{
+			lexeme:      startProcedureSectionLexeme,
+			kind:        sectionStartLexemeKind,
+			category:    procedureLexemeCategory,
+			name:        "START-PROC-SECT",
+			text:        "%(",
+			sectionEnd:  endProcedureSectionLexeme,
+			examples:    []string{"%(name"},
+			pattern:     `%\([A-Za-z0-9_]*`,
+			vimPattern:  `\%\(\w*`,
+			description: "Defines a procedure whose body is made up of the commands between the brackets, without running them. The name of the procedure is written directly after `%(` and is made up of up to `32` ASCII letters, digits and underscores (e.g. `%(fib` or `%(7`). A procedure can be defined anywhere in a program, including in an included `.dork` file, but each name can only be defined once.",
+		},
+		{
+			lexeme:      endProcedureSectionLexeme,
+			kind:        sectionEndLexemeKind,
+			category:    procedureLexemeCategory,
+			name:        "END-PROC-SECT",
+			text:        "%)",
+			description: "Defines a procedure whose body is made up of the commands between the brackets, without running them. The name of the procedure is written directly after `%(` and is made up of up to `32` ASCII letters, digits and underscores (e.g. `%(fib` or `%(7`). A procedure can be defined anywhere in a program, including in an included `.dork` file, but each name can only be defined once.",
+		},
+		{
+			lexeme:      startJumpIfPositiveSectionLexeme,
+			kind:        sectionStartLexemeKind,
+			category:    loopLexemeCategory,
+			name:        "START-JMP-IF-POS-SECT",
+			text:        "<",
+			sectionEnd:  endJumpIfPositiveSectionLexeme,
+			description: "Runs any commands between the brackets repeatedly while the **current value** does not equal `0`.",
+		},
+		{
+			lexeme:      endJumpIfPositiveSectionLexeme,
+			kind:        sectionEndLexemeKind,
+			category:    loopLexemeCategory,
+			name:        "END-JMP-IF-POS-SECT",
+			text:        ">",
+			description: "Runs any commands between the brackets repeatedly while the **current value** does not equal `0`.",
+		},
+		{
+			lexeme:      startJumpIfZeroSectionLexeme,
+			kind:        sectionStartLexemeKind,
+			category:    loopLexemeCategory,
+			name:        "START-JMP-IF-ZERO-SECT",
+			text:        "<<",
+			sectionEnd:  endJumpIfZeroSectionLexeme,
+			description: "Runs any commands between the brackets repeatedly while the **current value** equals `0`.",
+		},
+		{
+			lexeme:      endJumpIfZeroSectionLexeme,
+			kind:        sectionEndLexemeKind,
+			category:    loopLexemeCategory,
+			name:        "END-JMP-IF-ZERO-SECT",
+			text:        ">>",
+			description: "Runs any commands between the brackets repeatedly while the **current value** equals `0`.",
+		},
+		{
+			lexeme:      startIfSectionLexeme,
+			kind:        sectionStartLexemeKind,
+			category:    conditionLexemeCategory,
+			name:        "START-IF-SECT",
+			text:        "%?",
+			sectionEnd:  endIfSectionLexeme,
+			description: "Runs any commands between the brackets once if the **current value** does not equal `0`. If the section contains `%!`, the commands between `%!` and `%.` are run instead when the **current value** equals `0`.",
+		},
+		{
+			lexeme:      startIfZeroSectionLexeme,
+			kind:        sectionStartLexemeKind,
+			category:    conditionLexemeCategory,
+			name:        "START-IF-ZERO-SECT",
+			text:        "%??",
+			sectionEnd:  endIfSectionLexeme,
+			description: "Runs any commands between the brackets once if the **current value** equals `0`. If the section contains `%!`, the commands between `%!` and `%.` are run instead when the **current value** does not equal `0`.",
+		},
+		{
+			lexeme:      elseSectionLexeme,
+			kind:        sectionStartLexemeKind,
+			category:    conditionLexemeCategory,
+			name:        "ELSE-SECT",
+			text:        "%!",
+			sectionEnd:  endIfSectionLexeme,
+			description: "Separates the commands run by `%?` or `%??` when its condition holds from the commands run when it does not. It can only be used once, directly inside a `%?` or `%??` section.",
+		},
+		{
+			lexeme:      endIfSectionLexeme,
+			kind:        sectionEndLexemeKind,
+			category:    conditionLexemeCategory,
+			name:        "END-IF-SECT",
+			text:        "%.",
+			description: "Runs any commands between the brackets once if the **current value** does not equal `0`. If the section contains `%!`, the commands between `%!` and `%.` are run instead when the **current value** equals `0`.",
+		},
+		{
+			lexeme:      startCommentSectionLexeme,
+			kind:        sectionStartLexemeKind,
+			category:    commentLexemeCategory,
+			name:        "START-CMNT-SECT",
+			text:        "{",
+			sectionEnd:  endCommentSectionLexeme,
+			description: "Ignores all characters and commands between the braces, allowing for human-readable comments.",
+		},
+		{
+			lexeme:      endCommentSectionLexeme,
+			kind:        sectionEndLexemeKind,
+			category:    commentLexemeCategory,
+			name:        "END-CMNT-SECT",
+			text:        "}",
+			description: "Ignores all characters and commands between the braces, allowing for human-readable comments.",
+		},
+		{
+			lexeme:      startReadFileSectionLexeme,
+			kind:        sectionStartLexemeKind,
+			category:    includeLexemeCategory,
+			name:        "START-READ-FILE-SECT",
+			text:        "{{",
+			sectionEnd:  endReadFileSectionLexeme,
+			description: "Reads one or more files. The names of the files are given between the braces, separated by whitespace. If a file has a `.dork` extension, the commands it contains are run by the interpreter (keeping the same **current value** and stacks), but if a file has any other extension, the contents of the file are pushed onto the **current stack**. All commands within the braces are ignored. A `.dork` file cannot include itself, either directly or through other files, and files can only be included up to `64` levels deep, which can be changed with the `--max-include-depth` flag.",
+		},
+		{
+			lexeme:      endReadFileSectionLexeme,
+			kind:        sectionEndLexemeKind,
+			category:    includeLexemeCategory,
+			name:        "END-READ-FILE-SECT",
+			text:        "}}",
+			description: "Reads one or more files. The names of the files are given between the braces, separated by whitespace. If a file has a `.dork` extension, the commands it contains are run by the interpreter (keeping the same **current value** and stacks), but if a file has any other extension, the contents of the file are pushed onto the **current stack**. All commands within the braces are ignored. A `.dork` file cannot include itself, either directly or through other files, and files can only be included up to `64` levels deep, which can be changed with the `--max-include-depth` flag.",
+		},
+		{
+			lexeme: invalidLexeme,
+			kind:   internalLexemeKind,
+			name:   "INVALID",
+		},
+		{
+			lexeme: startProgramLexeme,
+			kind:   internalLexemeKind,
+			name:   "START-PROGRAM",
+		},
+		{
+			lexeme: endProgramLexeme,
+			kind:   internalLexemeKind,
+			name:   "END-PROGRAM",
+		},
+		{
+			lexeme:   filePathLexeme,
+			kind:     internalLexemeKind,
+			category: includeLexemeCategory,
+			name:     "FILE-PATH",
+		},
+		{
+			lexeme:   modifierLexeme,
+			kind:     modifierLexemeKind,
+			category: modifierLexemeCategory,
+			name:     "MODIFIER",
+			text:     "%",
+		},
+		{
+			lexeme: changeDirLexeme,
+			kind:   internalLexemeKind,
+			name:   "CHANGE-DIR",
+		},
+		{
+			lexeme: emptyLexeme,
+			kind:   internalLexemeKind,
+			name:   "EMPTY",
+		},
+		{
+			lexeme: parentLexeme,
+			kind:   internalLexemeKind,
+			name:   "PARENT",
+		},
+	}
+
+	lexemeDefinitionsByLexeme = newLexemeDefinitionsByLexeme(lexemeDefinitions)
+	lexemesByCommandText      = newLexemesByCommandText(lexemeDefinitions)
+	lexemeMerges              = newLexemeMerges(lexemeDefinitions)
+)
@@ -0,0 +1,73 @@
+package dorklang
+
+import "unicode/utf8"
+
+func newLexemeDefinitionsByLexeme(definitions []lexemeDefinition) (definitionsByLexeme map[lexeme]*lexemeDefinition) {
+	definitionsByLexeme = make(map[lexeme]*lexemeDefinition, len(definitions))
+
+	for i := range definitions {
+		definitionsByLexeme[definitions[i].lexeme] = &definitions[i]
+	}
+
+	return
+}
+
+func newLexemesByCommandText(definitions []lexemeDefinition) (lexemesByText map[string]lexeme) {
+	lexemesByText = make(map[string]lexeme, len(definitions))
+
+	for _, definition := range definitions {
+		if !definition.isProducedByCommandText() {
+			continue
+		}
+
+		lexemesByText[definition.text] = definition.lexeme
+	}
+
+	return
+}
+
+func newLexemeMerges(definitions []lexemeDefinition) (merges map[lexemeMerge]lexeme) {
+	merges = make(map[lexemeMerge]lexeme)
+	lexemesByText := newLexemesByCommandText(definitions)
+
+	for _, definition := range definitions {
+		if !definition.isProducedByCommandText() {
+			continue
+		}
+
+		r, size := utf8.DecodeLastRuneInString(definition.text)
+		prefix := definition.text[:len(definition.text)-size]
+
+		if previous, found := lexemesByText[prefix]; found {
+			merges[lexemeMerge{previous: previous, r: r}] = definition.lexeme
+		}
+	}
+
+	return
+}
+
+func lexemeAbsorbsRune(previousLexeme lexeme, r rune) (merged lexeme, found bool) {
+	merged, found = lexemeMerges[lexemeMerge{previous: previousLexeme, r: r}]
+
+	return
+}
+
+func lexemeAbsorbsText(previousLexeme lexeme, previousData []byte, text string) bool {
+	r, _ := utf8.DecodeRuneInString(text)
+
+	if previousLexeme == setLiteralLexeme && numericLiteralAbsorbsRune(previousData, r) {
+		return true
+	}
+
+	if previousLexeme == modifierLexeme && string(r) == stringLiteralStart[1:] {
+		return true
+	}
+
+	if previousLexeme.absorbsName() && nameAbsorbsRune(r) {
+		return true
+	}
+
+	_, found := lexemeAbsorbsRune(previousLexeme, r)
+
+	return found
+}
@@ -5,178 +5,24 @@ import (
 	"strings"
 )
 
-func (lexeme lexeme) String() string {
-	var builder strings.Builder
+func (lexeme lexeme) definition() (definition *lexemeDefinition, found bool) {
+	definition, found = lexemeDefinitionsByLexeme[lexeme]
 
-	switch lexeme {
-	case invalidLexeme:
-		builder.WriteString("INVALID")
-	case startProgramLexeme:
-		builder.WriteString("START-PROGRAM")
-	case endProgramLexeme:
-		builder.WriteString("END-PROGRAM")
-	case startAdditionSectionLexeme:
-		builder.WriteString("START-ADD-SECT")
-	case endAdditionSectionLexeme:
-		builder.WriteString("END-ADD-SECT")
-	case startSubtractionSectionLexeme:
-		builder.WriteString("START-SUB-SECT")
-	case endSubtractionSectionLexeme:
-		builder.WriteString("END-SUB-SECT")
-	case startMultiplicationSectionLexeme:
-		builder.WriteString("START-MULT-SECT")
-	case endMultiplicationSectionLexeme:
-		builder.WriteString("END-MULT-SECT")
-	case startDivisionSectionLexeme:
-		builder.WriteString("START-DIV-SECT")
-	case endDivisionSectionLexeme:
-		builder.WriteString("END-DIV-SECT")
-	case startJumpIfPositiveSectionLexeme:
-		builder.WriteString("START-JMP-IF-POS-SECT")
-	case endJumpIfPositiveSectionLexeme:
-		builder.WriteString("END-JMP-IF-POS-SECT")
-	case startJumpIfZeroSectionLexeme:
-		builder.WriteString("START-JMP-IF-ZERO-SECT")
-	case endJumpIfZeroSectionLexeme:
-		builder.WriteString("END-JMP-IF-ZERO-SECT")
-	case startCommentSectionLexeme:
-		builder.WriteString("START-CMNT-SECT")
-	case endCommentSectionLexeme:
-		builder.WriteString("END-CMNT-SECT")
-	case startReadFileSectionLexeme:
-		builder.WriteString("START-READ-FILE-SECT")
-	case endReadFileSectionLexeme:
-		builder.WriteString("END-READ-FILE-SECT")
-	case addOneLexeme:
-		builder.WriteString("ADD-ONE")
-	case addEightLexeme:
-		builder.WriteString("ADD-EIGHT")
-	case addStackPairLexeme:
-		builder.WriteString("ADD-STACK-PAIR")
-	case addStackWholeLexeme:
-		builder.WriteString("ADD-STACK-WHOLE")
-	case subtractOneLexeme:
-		builder.WriteString("SUB-ONE")
-	case subtractEightLexeme:
-		builder.WriteString("SUB-EIGHT")
-	case subtractStackPairLexeme:
-		builder.WriteString("SUB-STACK-PAIR")
-	case subtractStackWholeLexeme:
-		builder.WriteString("SUB-STACK-WHOLE")
-	case multiplyTwoLexeme:
-		builder.WriteString("MULT-TWO")
-	case multiplyEightLexeme:
-		builder.WriteString("MULT-EIGHT")
-	case multiplyStackPairLexeme:
-		builder.WriteString("MULT-STACK-PAIR")
-	case multiplyStackWholeLexeme:
-		builder.WriteString("MULT-STACK-WHOLE")
-	case divideTwoLexeme:
-		builder.WriteString("DIV-TWO")
-	case divideEightLexeme:
-		builder.WriteString("DIV-EIGHT")
-	case divideStackPairLexeme:
-		builder.WriteString("DIV-STACK-PAIR")
-	case divideStackWholeLexeme:
-		builder.WriteString("DIV-STACK-WHOLE")
-	case squareLexeme:
-		builder.WriteString("SQUARE")
-	case cubeLexeme:
-		builder.WriteString("CUBE")
-	case setZeroLexeme:
-		builder.WriteString("SET-ZERO")
-	case setOneByteLexeme:
-		builder.WriteString("SET-ONE-BYTE")
-	case setEightByteLexeme:
-		builder.WriteString("SET-EIGHT-BYTE")
-	case setOneKibibyteLexeme:
-		builder.WriteString("SET-ONE-KIBI")
-	case setEightKibibyteLexeme:
-		builder.WriteString("SET-EIGHT-KIBI")
-	case setOneMebibyteLexeme:
-		builder.WriteString("SET-ONE-MEBI")
-	case setEightMebibyteLexeme:
-		builder.WriteString("SET-EIGHT-MEBI")
-	case setOneGibibyteLexeme:
-		builder.WriteString("SET-ONE-GIBI")
-	case setEightGibibyteLexeme:
-		builder.WriteString("SET-EIGHT-GIBI")
-	case setRandomByteLexeme:
-		builder.WriteString("SET-RAND-BYTE")
-	case setRandomMaxLexeme:
-		builder.WriteString("SET-RAND-MAX")
-	case setSecondTimestampLexeme:
-		builder.WriteString("SET-SEC-TIME")
-	case setNanosecondTimestampLexeme:
-		builder.WriteString("SET-NANO-TIME")
-	case printCharacterLexeme:
-		builder.WriteString("PRINT-CHAR")
-	case printNumberLexeme:
-		builder.WriteString("PRINT-NUM")
-	case inputCharacterLexeme:
-		builder.WriteString("INPUT-CHAR")
-	case inputNumberLexeme:
-		builder.WriteString("INPUT-NUM")
-	case logicalAndStackPairLexeme:
-		builder.WriteString("LOGIC-AND-STACK-PAIR")
-	case logicalAndStackWholeLexeme:
-		builder.WriteString("LOGIC-AND-STACK-WHOLE")
-	case iotaFromZeroLexeme:
-		builder.WriteString("IOTA-ZERO")
-	case iotaFromOneLexeme:
-		builder.WriteString("IOTA-ONE")
-	case writeStackToFileLexeme:
-		builder.WriteString("WRITE-STACK-FILE")
-	case readStackFromFileLexeme:
-		builder.WriteString("READ-STACK-FILE")
-	case deleteFileLexeme:
-		builder.WriteString("DELETE-STACK-FILE")
-	case clearStackLexeme:
-		builder.WriteString("CLEAR-STACK")
-	case pushStackLexeme:
-		builder.WriteString("PUSH-STACK")
-	case popStackLastLexeme:
-		builder.WriteString("POP-STACK-LAST")
-	case popStackRandomLexeme:
-		builder.WriteString("POP-STACK-RAND")
-	case countStackLexeme:
-		builder.WriteString("COUNT-STACK")
-	case useStackIndexZeroLexeme:
-		builder.WriteString("USE-STACK-ZERO")
-	case useStackIndexOneLexeme:
-		builder.WriteString("USE-STACK-ONE")
-	case useStackIndexSwappedLexeme:
-		builder.WriteString("USE-STACK-SWAPPED")
-	case hashStackOneByteLexeme:
-		builder.WriteString("HASH-STACK-ONE-BYTE")
-	case hashStackEightByteLexeme:
-		builder.WriteString("HASH-STACK-EIGHT-BYTE")
-	case sortStackAscendingLexeme:
-		builder.WriteString("SORT-STACK-ASC")
-	case sortStackDescendingLexeme:
-		builder.WriteString("SORT-STACK-DESC")
-	case shuffleStackLexeme:
-		builder.WriteString("SHUFFLE-STACK")
-	case swapStackTopLexeme:
-		builder.WriteString("SWAP-STACK-TOP")
-	case reverseStackLexeme:
-		builder.WriteString("REVERSE-STACK")
-	case invertLexeme:
-		builder.WriteString("INVERT")
-	case modifierLexeme:
-		builder.WriteString("MODIFIER")
-	case filePathLexeme:
-		builder.WriteString("FILE-PATH")
-	case parentLexeme:
-		builder.WriteString("PARENT")
-	case separatorLexeme:
-		builder.WriteString("SEP")
-	case emptyLexeme:
-		builder.WriteString("EMPTY")
-	default:
-		builder.WriteString("UNKNOWN")
+	return
+}
+
+func (lexeme lexeme) name() string {
+	if definition, found := lexeme.definition(); found {
+		return definition.name
 	}
 
+	return "UNKNOWN"
+}
+
+func (lexeme lexeme) String() string {
+	var builder strings.Builder
+
+	builder.WriteString(lexeme.name())
 	builder.WriteByte(' ')
 	builder.WriteByte('[')
 	builder.WriteString(strconv.FormatUint(uint64(lexeme), 10))
@@ -184,3 +30,133 @@ func (lexeme lexeme) String() string {
 
 	return builder.String()
 }
+
+func (lexeme lexeme) sourceText() string {
+	if definition, found := lexeme.definition(); found && definition.kind != internalLexemeKind {
+		return definition.text
+	}
+
+	return ""
+}
+
+func (lexeme lexeme) isSection() bool {
+	if definition, found := lexeme.definition(); found {
+		switch definition.kind {
+		case sectionStartLexemeKind,
+			sectionEndLexemeKind:
+			return true
+		}
+	}
+
+	return false
+}
+
+func (lexeme lexeme) sectionEndLexeme() lexeme {
+	if definition, found := lexeme.definition(); found && definition.kind == sectionStartLexemeKind {
+		return definition.sectionEnd
+	}
+
+	return invalidLexeme
+}
+
+func (lexeme lexeme) stackEffect() lexemeStackEffect {
+	if definition, found := lexeme.definition(); found {
+		return definition.stackEffect
+	}
+
+	return lexemeStackEffect{}
+}
+
+// the name that follows these lexemes is kept in the data of their tokens
+func (lexeme lexeme) absorbsName() bool {
+	switch lexeme {
+	case startProcedureSectionLexeme,
+		callProcedureLexeme,
+		setOverflowModeLexeme:
+		return true
+	}
+
+	return false
+}
+
+func (lexeme lexeme) isMerged() bool {
+	text := lexeme.sourceText()
+	if len(text) < 2 {
+		return false
+	}
+
+	previousLexeme, found := lexemesByCommandText[text[:len(text)-1]]
+	if !found {
+		return false
+	}
+
+	merged, found := lexemeAbsorbsRune(previousLexeme, rune(text[len(text)-1]))
+
+	return found && merged == lexeme
+}
+
+func (lexeme lexeme) isPureValueCommand() bool {
+	switch lexeme {
+	case addOneLexeme,
+		addEightLexeme,
+		subtractOneLexeme,
+		subtractEightLexeme,
+		multiplyTwoLexeme,
+		multiplyEightLexeme,
+		divideTwoLexeme,
+		divideEightLexeme,
+		moduloTwoLexeme,
+		moduloEightLexeme,
+		squareLexeme,
+		cubeLexeme,
+		bitwiseNotLexeme,
+		bitCountLexeme,
+		shiftLeftOneLexeme,
+		shiftLeftEightLexeme,
+		shiftRightOneLexeme,
+		shiftRightEightLexeme,
+		invertLexeme:
+		return true
+	}
+
+	return false
+}
+
+func (lexeme lexeme) isConstantValueCommand() bool {
+	switch lexeme {
+	case setZeroLexeme,
+		setOneByteLexeme,
+		setEightByteLexeme,
+		setOneKibibyteLexeme,
+		setEightKibibyteLexeme,
+		setOneMebibyteLexeme,
+		setEightMebibyteLexeme,
+		setOneGibibyteLexeme,
+		setEightGibibyteLexeme,
+		setLiteralLexeme:
+		return true
+	}
+
+	return false
+}
+
+func (lexeme lexeme) description() string {
+	if definition, found := lexeme.definition(); found {
+		return definition.description
+	}
+
+	return ""
+}
+
+func (definition lexemeDefinition) isProducedByCommandText() bool {
+	switch definition.kind {
+	case commandLexemeKind,
+		modifierLexemeKind,
+		sectionStartLexemeKind:
+		return definition.category != includeLexemeCategory
+	case sectionEndLexemeKind:
+		return definition.category != includeLexemeCategory && definition.category != commentLexemeCategory
+	}
+
+	return false
+}
@@ -0,0 +1,26 @@
+package dorklang
+
+import "bufio"
+
+type tokenLexer struct {
+	reader       *bufio.Reader
+	position     Position
+	modeStack    []lexeme // only holds comment, read-file and string sections, which change how runes are lexed
+	sectionStack []lexeme
+	pending      token // the latest token, which can still be changed by the runes that follow it
+	pendingFound bool
+	escape       stringEscapeState
+	ready        token
+	readyFound   bool
+	finished     bool
+	ended        bool
+}
+
+type stringEscapeState int
+
+const (
+	stringEscapeNone stringEscapeState = iota
+	stringEscapeStarted
+	stringEscapeUnicode
+	stringEscapeUnicodeBraced
+)
@@ -0,0 +1,37 @@
+package dorklang
+
+import (
+	"bufio"
+	"io"
+	"unicode/utf8"
+)
+
+func newTokenLexer(reader io.Reader, filePath string) (lexer *tokenLexer) {
+	position := Position{
+		FilePath: filePath,
+		Line:     1,
+		Column:   1,
+	}
+
+	lexer = &tokenLexer{
+		reader:   bufio.NewReader(reader),
+		position: position,
+		pending: token{
+			lex:  startProgramLexeme,
+			span: sourceSpan{start: position, end: position},
+		},
+		pendingFound: true,
+	}
+
+	return
+}
+
+func appendSourceRune(data []byte, r rune, size int, invalidByte byte) (output []byte) {
+	if size == 1 && r == utf8.RuneError {
+		output = append(data, invalidByte)
+	} else {
+		output = utf8.AppendRune(data, r)
+	}
+
+	return
+}
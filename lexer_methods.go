@@ -0,0 +1,368 @@
+package dorklang
+
+import (
+	"errors"
+	"io"
+	"unicode"
+	"unicode/utf8"
+)
+
+func (lexer *tokenLexer) next() (t token, err error) {
+	for !lexer.readyFound {
+		if lexer.ended {
+			err = io.EOF
+			return
+		}
+
+		if err = lexer.step(); err != nil {
+			var positionErr *PositionError
+
+			if errors.As(err, &positionErr) {
+				return
+			}
+
+			if sectionErr := lexer.finalize(); sectionErr != nil {
+				err = sectionErr
+				return
+			}
+
+			err = &PositionError{Position: lexer.position, Err: err}
+			return
+		}
+	}
+
+	t = lexer.ready
+	lexer.ready = token{}
+	lexer.readyFound = false
+
+	return
+}
+
+func (lexer *tokenLexer) finalize() (err error) {
+	if !lexer.pendingFound {
+		return
+	}
+
+	t := lexer.pending
+
+	switch t.lex {
+	case setLiteralLexeme:
+		_, err = parseNumericLiteral(t.data)
+	case pushStringLexeme:
+		_, err = decodeStringLiteral(t.data)
+	case startProcedureSectionLexeme,
+		callProcedureLexeme:
+		err = checkProcedureName(t.data)
+	case setOverflowModeLexeme:
+		_, err = ParseOverflowMode(string(t.data))
+	case breakLoopLexeme,
+		continueLoopLexeme:
+		err = lexer.checkInsideLoop()
+	}
+	if err != nil {
+		err = &PositionError{Position: t.span.start, Err: err}
+		return
+	}
+
+	switch {
+	case t.lex == elseSectionLexeme:
+		{
+			if len(lexer.sectionStack) == 0 {
+				err = &PositionError{Position: t.span.start, Err: ErrNoMatchSectionCharacters}
+				return
+			}
+
+			// an else section replaces the if section that it is directly inside, so it can only be used once
+			switch lexer.sectionStack[len(lexer.sectionStack)-1] {
+			case startIfSectionLexeme,
+				startIfZeroSectionLexeme:
+				lexer.sectionStack[len(lexer.sectionStack)-1] = t.lex
+			default:
+				err = &PositionError{Position: t.span.start, Err: ErrLexemeSectionStackNoMatch}
+				return
+			}
+		}
+	case t.lex.sectionEndLexeme() != invalidLexeme:
+		lexer.sectionStack = append(lexer.sectionStack, t.lex)
+	case t.lex.isSection():
+		{
+			if len(lexer.sectionStack) == 0 {
+				err = &PositionError{Position: t.span.start, Err: ErrNoMatchSectionCharacters}
+				return
+			}
+
+			if lexer.sectionStack[len(lexer.sectionStack)-1].sectionEndLexeme() != t.lex {
+				err = &PositionError{Position: t.span.start, Err: ErrLexemeSectionStackNoMatch}
+				return
+			}
+
+			lexer.sectionStack = lexer.sectionStack[:len(lexer.sectionStack)-1]
+		}
+	}
+
+	lexer.ready = t
+	lexer.readyFound = true
+	lexer.pending = token{}
+	lexer.pendingFound = false
+
+	return
+}
+
+// a procedure body is run where it is called, so a loop surrounding its definition does not count
+func (lexer *tokenLexer) checkInsideLoop() (err error) {
+	for i := len(lexer.sectionStack) - 1; i >= 0 && lexer.sectionStack[i] != startProcedureSectionLexeme; i-- {
+		switch lexer.sectionStack[i] {
+		case startJumpIfPositiveSectionLexeme,
+			startJumpIfZeroSectionLexeme:
+			return
+		}
+	}
+
+	err = ErrLoopCommandOutsideLoop
+
+	return
+}
+
+// errors from the reader are given the position that was reached, so they are not hidden by a section that it left unfinished
+func (lexer *tokenLexer) readRune() (r rune, size int, err error) {
+	r, size, err = lexer.reader.ReadRune()
+	if err != nil && err != io.EOF {
+		err = &PositionError{Position: lexer.position, Err: err}
+	}
+
+	return
+}
+
+// a `&` that is not followed by a name is ignored, as it was before procedures could be called
+func (lexer *tokenLexer) nameFollows() (found bool, err error) {
+	r, _, err := lexer.readRune()
+	if err == io.EOF {
+		err = nil
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	found = nameAbsorbsRune(r)
+	err = lexer.reader.UnreadRune()
+
+	return
+}
+
+func (lexer *tokenLexer) step() (err error) {
+	if lexer.finished {
+		err = lexer.finalize()
+		lexer.ended = true
+		return
+	}
+
+	r, size, err := lexer.readRune()
+	if err == io.EOF {
+		err = lexer.end()
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	var invalidByte byte
+	if r == utf8.RuneError && size == 1 {
+		if err = lexer.reader.UnreadRune(); err != nil {
+			return
+		}
+
+		if invalidByte, err = lexer.reader.ReadByte(); err != nil {
+			return
+		}
+	}
+
+	l := invalidLexeme
+	var d []byte
+	width := 1
+
+	modeStackTopLexeme := invalidLexeme
+	if len(lexer.modeStack) > 0 {
+		modeStackTopLexeme = lexer.modeStack[len(lexer.modeStack)-1]
+	}
+
+	lastLexeme := lexer.pending.lex
+
+	switch modeStackTopLexeme {
+	case pushStringLexeme:
+		if lexer.escape == stringEscapeNone && string(r) == stringLiteralEnd {
+			lexer.modeStack = lexer.modeStack[:len(lexer.modeStack)-1]
+			break
+		}
+
+		lexer.escape = lexer.escape.next(r)
+		lexer.pending.data = appendSourceRune(lexer.pending.data, r, size, invalidByte)
+	case startCommentSectionLexeme:
+		switch r {
+		case '{':
+			if lexer.pending.lex == startCommentSectionLexeme {
+				lexer.modeStack[len(lexer.modeStack)-1] = startReadFileSectionLexeme
+				lexer.pending.lex = startReadFileSectionLexeme
+			} else {
+				l = startCommentSectionLexeme
+				lexer.modeStack = append(lexer.modeStack, l)
+			}
+		case '}':
+			l = endCommentSectionLexeme
+			lexer.modeStack = lexer.modeStack[:len(lexer.modeStack)-1]
+		}
+	case startReadFileSectionLexeme:
+		switch {
+		case r == '}':
+			{
+				var r2 rune
+				var size2 int
+
+				r2, size2, err = lexer.readRune()
+				if err != nil && err != io.EOF {
+					return
+				}
+				if err == io.EOF || r2 != r {
+					err = ErrLexemeSectionStackNoMatch
+					return
+				}
+
+				size += size2
+				width = 2
+
+				l = endReadFileSectionLexeme
+				lexer.modeStack = lexer.modeStack[:len(lexer.modeStack)-1]
+			}
+		case unicode.IsSpace(r):
+			if lexer.pending.lex != separatorLexeme {
+				l = separatorLexeme
+			}
+		default:
+			{
+				var target *[]byte
+
+				if lexer.pending.lex == filePathLexeme {
+					target = &lexer.pending.data
+				} else {
+					l = filePathLexeme
+					target = &d
+				}
+
+				*target = appendSourceRune(*target, r, size, invalidByte)
+			}
+		}
+	default:
+		callFollows := true
+		if string(r) == callProcedureLexeme.sourceText() {
+			if callFollows, err = lexer.nameFollows(); err != nil {
+				return
+			}
+		}
+
+		if unicode.IsSpace(r) {
+			if lexer.pending.lex != separatorLexeme {
+				l = separatorLexeme
+			}
+		} else if lexer.pending.lex == modifierLexeme && string(r) == stringLiteralStart[1:] {
+			lexer.pending.lex = pushStringLexeme
+			lexer.modeStack = append(lexer.modeStack, pushStringLexeme)
+		} else if lexer.pending.lex.absorbsName() && nameAbsorbsRune(r) {
+			lexer.pending.data = append(lexer.pending.data, byte(r))
+		} else if lexer.pending.lex == setLiteralLexeme && numericLiteralAbsorbsRune(lexer.pending.data, r) {
+			lexer.pending.data = append(lexer.pending.data, byte(r))
+		} else if r >= '0' && r <= '9' {
+			l = setLiteralLexeme
+			d = append(d, byte(r))
+		} else if merged, found := lexemeAbsorbsRune(lastLexeme, r); found {
+			lexer.pending.lex = merged
+		} else if l2, found := lexemesByCommandText[string(r)]; found && (l2 != callProcedureLexeme || callFollows) {
+			l = l2
+
+			if l == startCommentSectionLexeme {
+				lexer.modeStack = append(lexer.modeStack, l)
+			}
+		} else if l2 != callProcedureLexeme {
+			// only a bare call with no name after it is skipped
+			err = ErrLexemeUnrecognized
+			return
+		}
+	}
+
+	position := lexer.position
+
+	endPosition := position
+	endPosition.Offset += size
+	if r == '\n' {
+		endPosition.Line++
+		endPosition.Column = 1
+	} else {
+		endPosition.Column += width
+	}
+
+	if l != invalidLexeme {
+		if err = lexer.finalize(); err != nil {
+			return
+		}
+
+		lexer.pending = token{
+			lex:  l,
+			data: d,
+			span: sourceSpan{start: position, end: endPosition},
+		}
+		lexer.pendingFound = true
+	} else if modeStackTopLexeme != startCommentSectionLexeme || lexer.pending.lex != lastLexeme {
+		lexer.pending.span.end = endPosition
+	}
+
+	lexer.position = endPosition
+
+	return
+}
+
+func (lexer *tokenLexer) end() (err error) {
+	if len(lexer.modeStack) != 0 {
+		err = ErrNoMatchSectionCharacters
+		return
+	}
+
+	if err = lexer.finalize(); err != nil {
+		return
+	}
+
+	if len(lexer.sectionStack) != 0 {
+		err = &PositionError{Position: lexer.position, Err: ErrNoMatchSectionCharacters}
+		return
+	}
+
+	lexer.pending = token{
+		lex:  endProgramLexeme,
+		span: sourceSpan{start: lexer.position, end: lexer.position},
+	}
+	lexer.pendingFound = true
+	lexer.finished = true
+
+	return
+}
+
+func (state stringEscapeState) next(r rune) (output stringEscapeState) {
+	switch state {
+	case stringEscapeNone:
+		if r == stringLiteralEscape {
+			output = stringEscapeStarted
+		}
+	case stringEscapeStarted:
+		if r == 'u' {
+			output = stringEscapeUnicode
+		}
+	case stringEscapeUnicode:
+		if r == '{' {
+			output = stringEscapeUnicodeBraced
+		}
+	case stringEscapeUnicodeBraced:
+		if string(r) != stringLiteralEnd {
+			output = stringEscapeUnicodeBraced
+		}
+	}
+
+	return
+}
@@ -0,0 +1,415 @@
+package dorklang
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var updateTokenStreams = flag.Bool("update", false, "rewrite the expected token streams in testdata")
+
+// each token is written on its own line, so a change to any token shows up as a small diff
+func tokenStreamText(tokens tokenCollection) string {
+	var builder strings.Builder
+
+	for _, t := range tokens {
+		fmt.Fprintf(
+			&builder,
+			"%s %q %d:%d+%d %d:%d+%d\n",
+			t.lex.name(),
+			t.data,
+			t.span.start.Line, t.span.start.Column, t.span.start.Offset,
+			t.span.end.Line, t.span.end.Column, t.span.end.Offset,
+		)
+	}
+
+	return builder.String()
+}
+
+// the same as tokenStreamText without the spans, which the hand-written lexer did not record
+func tokenLexemeText(tokens tokenCollection) string {
+	var builder strings.Builder
+
+	for _, t := range tokens {
+		fmt.Fprintf(&builder, "%s %q\n", t.lex.name(), t.data)
+	}
+
+	return builder.String()
+}
+
+func TestProduceTokensMatchesExamples(t *testing.T) {
+	for filePath, content := range exampleSources(t) {
+		t.Run(filePath, func(t *testing.T) {
+			tokens, err := produceTokens(content, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			actual := tokenStreamText(tokens)
+			expectedFilePath := filepath.Join("testdata", "tokens", strings.TrimSuffix(filepath.Base(filePath), FileExtensionForCode)+".tokens")
+
+			if *updateTokenStreams {
+				if err = os.WriteFile(expectedFilePath, []byte(actual), 0o644); err != nil {
+					t.Fatal(err)
+				}
+
+				return
+			}
+
+			expected, err := os.ReadFile(expectedFilePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if actual != string(expected) {
+				t.Errorf("tokens do not match %s:\n%s", expectedFilePath, actual)
+			}
+		})
+	}
+}
+
+// the streams in testdata/tokens/baseline were written by the hand-written lexer that came before the command
+// registry, so they are never rewritten by -update and only compare the lexemes and their data
+func TestProduceTokensMatchesBaseline(t *testing.T) {
+	baselineFilePaths, err := filepath.Glob(filepath.Join("testdata", "tokens", "baseline", "*.tokens"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(baselineFilePaths) == 0 {
+		t.Fatal("no baseline token streams found")
+	}
+
+	for _, baselineFilePath := range baselineFilePaths {
+		t.Run(baselineFilePath, func(t *testing.T) {
+			content, err := os.ReadFile(filepath.Join("examples", strings.TrimSuffix(filepath.Base(baselineFilePath), ".tokens")+FileExtensionForCode))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tokens, err := produceTokens(content, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			actual := tokenLexemeText(tokens)
+
+			expected, err := os.ReadFile(baselineFilePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if actual != string(expected) {
+				t.Errorf("tokens do not match %s:\n%s", baselineFilePath, actual)
+			}
+		})
+	}
+}
+
+func TestProduceTokensEdgeCases(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:  "modulo section before percent",
+			input: `%[+%]%`,
+			expected: `START-PROGRAM "" 1:1+0 1:1+0
+START-MOD-SECT "" 1:1+0 1:3+2
+ADD-ONE "" 1:3+2 1:4+3
+END-MOD-SECT "" 1:4+3 1:6+5
+MODIFIER "" 1:6+5 1:7+6
+END-PROGRAM "" 1:7+6 1:7+6
+`,
+		},
+		{
+			name:  "loop prefixes",
+			input: `<<+>> <+>`,
+			expected: `START-PROGRAM "" 1:1+0 1:1+0
+START-JMP-IF-ZERO-SECT "" 1:1+0 1:3+2
+ADD-ONE "" 1:3+2 1:4+3
+END-JMP-IF-ZERO-SECT "" 1:4+3 1:6+5
+SEP "" 1:6+5 1:7+6
+START-JMP-IF-POS-SECT "" 1:7+6 1:8+7
+ADD-ONE "" 1:8+7 1:9+8
+END-JMP-IF-POS-SECT "" 1:9+8 1:10+9
+END-PROGRAM "" 1:10+9 1:10+9
+`,
+		},
+		{
+			name:  "literals",
+			input: `26 0x1a 0XfF 7x`,
+			expected: `START-PROGRAM "" 1:1+0 1:1+0
+SET-LITERAL "26" 1:1+0 1:3+2
+SEP "" 1:3+2 1:4+3
+SET-LITERAL "0x1a" 1:4+3 1:8+7
+SEP "" 1:8+7 1:9+8
+SET-LITERAL "0XfF" 1:9+8 1:13+12
+SEP "" 1:13+12 1:14+13
+SET-LITERAL "7" 1:14+13 1:15+14
+SWAP-STACK-TOP "" 1:15+14 1:16+15
+END-PROGRAM "" 1:16+15 1:16+15
+`,
+		},
+		{
+			name:  "names",
+			input: "%(fib_2\n&fib_2%)&fib_2+",
+			expected: `START-PROGRAM "" 1:1+0 1:1+0
+START-PROC-SECT "fib_2" 1:1+0 1:8+7
+SEP "" 1:8+7 2:1+8
+CALL-PROC "fib_2" 2:1+8 2:7+14
+END-PROC-SECT "" 2:7+14 2:9+16
+CALL-PROC "fib_2" 2:9+16 2:15+22
+ADD-ONE "" 2:15+22 2:16+23
+END-PROGRAM "" 2:16+23 2:16+23
+`,
+		},
+		{
+			name:  "call without a name",
+			input: `+&+ &fib &`,
+			expected: `START-PROGRAM "" 1:1+0 1:1+0
+ADD-EIGHT "" 1:1+0 1:4+3
+SEP "" 1:4+3 1:5+4
+CALL-PROC "fib" 1:5+4 1:9+8
+SEP "" 1:9+8 1:11+10
+END-PROGRAM "" 1:11+10 1:11+10
+`,
+		},
+		{
+			name:  "else",
+			input: `%?+%!-%.`,
+			expected: `START-PROGRAM "" 1:1+0 1:1+0
+START-IF-SECT "" 1:1+0 1:3+2
+ADD-ONE "" 1:3+2 1:4+3
+ELSE-SECT "" 1:4+3 1:6+5
+SUB-ONE "" 1:6+5 1:7+6
+END-IF-SECT "" 1:7+6 1:9+8
+END-PROGRAM "" 1:9+8 1:9+8
+`,
+		},
+		{
+			name:  "multi-byte string",
+			input: "%{é\\}\U0001F600}+",
+			expected: `START-PROGRAM "" 1:1+0 1:1+0
+PUSH-STRING "é\\}😀" 1:1+0 1:8+11
+ADD-ONE "" 1:8+11 1:9+12
+END-PROGRAM "" 1:9+12 1:9+12
+`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			tokens, err := produceTokens([]byte(testCase.input), "")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if actual := tokenStreamText(tokens); actual != testCase.expected {
+				t.Errorf("unexpected tokens for %q:\n%s", testCase.input, actual)
+			}
+		})
+	}
+}
+
+func TestProduceTokensErrors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		err      error
+		position Position
+	}{
+		{
+			name:     "unterminated string",
+			input:    "+\n%{abc",
+			err:      ErrNoMatchSectionCharacters,
+			position: Position{Offset: 7, Line: 2, Column: 6},
+		},
+		{
+			name:     "unterminated comment",
+			input:    "{ abc",
+			err:      ErrNoMatchSectionCharacters,
+			position: Position{Offset: 5, Line: 1, Column: 6},
+		},
+		{
+			name:     "else outside if",
+			input:    "+%!",
+			err:      ErrNoMatchSectionCharacters,
+			position: Position{Offset: 1, Line: 1, Column: 2},
+		},
+		{
+			name:     "second else",
+			input:    "%?%!%!%.",
+			err:      ErrLexemeSectionStackNoMatch,
+			position: Position{Offset: 4, Line: 1, Column: 5},
+		},
+		{
+			name:     "literal out of range",
+			input:    "18446744073709551616",
+			err:      ErrNumericLiteralOutOfRange,
+			position: Position{Offset: 0, Line: 1, Column: 1},
+		},
+		{
+			name:     "bare a",
+			input:    "+a!!",
+			err:      ErrLexemeUnrecognized,
+			position: Position{Offset: 1, Line: 1, Column: 2},
+		},
+		{
+			name:     "bare v",
+			input:    "+\nv",
+			err:      ErrLexemeUnrecognized,
+			position: Position{Offset: 2, Line: 2, Column: 1},
+		},
+		{
+			name:     "bare equals",
+			input:    "+ = +",
+			err:      ErrLexemeUnrecognized,
+			position: Position{Offset: 2, Line: 1, Column: 3},
+		},
+		{
+			name:     "bare c",
+			input:    "+ < %c c >",
+			err:      ErrLexemeUnrecognized,
+			position: Position{Offset: 7, Line: 1, Column: 8},
+		},
+		{
+			name:     "bare a after a merged command",
+			input:    "%aa a",
+			err:      ErrLexemeUnrecognized,
+			position: Position{Offset: 4, Line: 1, Column: 5},
+		},
+		{
+			name:     "unknown command after percent",
+			input:    "+%y",
+			err:      ErrLexemeUnrecognized,
+			position: Position{Offset: 2, Line: 1, Column: 3},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := produceTokens([]byte(testCase.input), "")
+			if !errors.Is(err, testCase.err) {
+				t.Fatalf("expected %v, got %v", testCase.err, err)
+			}
+
+			var positionErr *PositionError
+			if !errors.As(err, &positionErr) {
+				t.Fatalf("expected a position, got %v", err)
+			}
+
+			if positionErr.Position != testCase.position {
+				t.Errorf("expected position %+v, got %+v", testCase.position, positionErr.Position)
+			}
+		})
+	}
+}
+
+// the expected results were taken from the interpreter before the command registry, so merged commands and
+// sections keep behaving as they did when the lexer was written by hand
+func TestInterpretCodeMergesAndSections(t *testing.T) {
+	testCases := []struct {
+		source   string
+		expected uint64
+		printed  string
+	}{
+		{"+++", 9, ""},
+		{"++ +", 9, ""},
+		{"+ ++", 9, ""},
+		{"++++", 16, ""},
+		{"+ + +", 3, ""},
+		{"'--", 0, ""},
+		{"'- -", 6, ""},
+		{"'//", 1, ""},
+		{"'/ /", 2, ""},
+		{"+**", 8, ""},
+		{"+* *", 4, ""},
+		{"++ +^", 81, ""},
+		{"++ +^^", 729, ""},
+		{"++ + ^ ^", 6561, ""},
+		{"''", 64, ""},
+		{"' '", 8, ""},
+		{"\"\"", 65536, ""},
+		{"%''", 67108864, ""},
+		{"%\"\"", 68719476736, ""},
+		{"+!!", 1, "1"},
+		{"++ ++ ++ ++ + !", 33, "!"},
+		{"+ ! !", 1, "\x01\x01"},
+		{"+: ++ : %+", 10, ""},
+		{"+: ++ : %++", 10, ""},
+		{"+: ++ : + : %++", 20, ""},
+		{"+: ++ : %*", 9, ""},
+		{"+: ++ : + : %**", 90, ""},
+		{"++ : + : %-", 1, ""},
+		{"++ : + : ++ : %--", 0, ""},
+		{"''  : ++ : %/", 1, ""},
+		{"'' : ++ : * : %//", 0, ""},
+		{"+ : ++ : %&", 1, ""},
+		{"+ : ++ : + : %&&", 1, ""},
+		{"+ : $$ ++ : $ ;", 1, ""},
+		{"+ : $$ ++ : %$ ;", 1, ""},
+		{"+ : + : %:", 2, ""},
+		{"++ ii %++", 28, ""},
+		{"++ i %++", 28, ""},
+		{"+++ ii %**", 40320, ""},
+		{"(+++)", 9, ""},
+		{"(+++) +", 10, ""},
+		{"((+++)) ++", 8, ""},
+		{"+ ((++))", 8, ""},
+		{"++ [+++]", 18446744073709551615, ""},
+		{"++ [[+++]]", 0, ""},
+		{"++ [[ [+] ]]", 0, ""},
+		{"''< - >", 0, ""},
+		{"++<-:>%++", 28, ""},
+		{"<<+>>", 1, ""},
+		{"+ <<+>>", 1, ""},
+		{"+ {comment ++} +", 2, ""},
+		{"++ +\\", 0, ""},
+		{"\\", 1, ""},
+		{"+ \\\\", 1, ""},
+		{"%| +", 1, ""},
+		{"++ : + : x ;", 8, ""},
+		{"+ : ++ : + : r ;", 1, ""},
+		{"+ : +++ : ++ : s ;", 18, ""},
+		{"+ : +++ : ++ : ss ;", 1, ""},
+		{"+ : ++ : || %:", 0, ""},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.source, func(t *testing.T) {
+			initialDir, err := os.Getwd()
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() {
+				os.Chdir(initialDir)
+			})
+
+			var printed strings.Builder
+
+			options := InterpretCodeDefaultOptions.Clone()
+			options.WorkingDir = t.TempDir()
+			options.Input = strings.NewReader("")
+			options.Output = &printed
+
+			output, err := InterpretCode([]byte(testCase.source), options)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if output != testCase.expected {
+				t.Errorf("expected %d, got %d", testCase.expected, output)
+			}
+
+			if printed.String() != testCase.printed {
+				t.Errorf("expected %q to be printed, got %q", testCase.printed, printed.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package dorklang
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+)
+
+const (
+	lspJSONRPCVersion                = "2.0"
+	lspContentLengthHeader           = "Content-Length"
+	lspServerName                    = "dorklang"
+	lspDiagnosticSource              = "dorklang"
+	lspErrorCodeParse                = -32700
+	lspErrorCodeInvalidRequest       = -32600
+	lspErrorCodeMethodNotFound       = -32601
+	lspErrorCodeInvalidParams        = -32602
+	lspErrorCodeRequestFailed        = -32803
+	lspTextDocumentSyncFull          = 1
+	lspDiagnosticSeverityError       = 1
+	lspDiagnosticSeverityWarning     = 2
+	lspDocumentHighlightKindText     = 1
+	lspFoldingRangeKindComment       = "comment"
+	lspFoldingRangeKindRegion        = "region"
+	lspMarkupKindMarkdown            = "markdown"
+	lspURIScheme                     = "file"
+	lspMessageContentLengthMaxLength = 1 << 26 // 67_108_864
+)
+
+type lspServer struct {
+	reader      *bufio.Reader
+	writer      io.Writer
+	documents   map[string]*lspDocument
+	initialized bool
+	shutdown    bool
+	exited      bool
+}
+
+type lspDocument struct {
+	uri         string
+	filePath    string
+	text        []byte
+	lineOffsets []int
+}
+
+type lspRequest struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Method  string           `json:"method"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+}
+
+type lspResponse struct {
+	JSONRPC string            `json:"jsonrpc"`
+	ID      *json.RawMessage  `json:"id"`
+	Result  json.RawMessage   `json:"result,omitempty"`
+	Error   *lspResponseError `json:"error,omitempty"`
+}
+
+type lspResponseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type lspNotification struct {
+	JSONRPC string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  any    `json:"params"`
+}
+
+type lspPosition struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type lspRange struct {
+	Start lspPosition `json:"start"`
+	End   lspPosition `json:"end"`
+}
+
+type lspLocation struct {
+	URI   string   `json:"uri"`
+	Range lspRange `json:"range"`
+}
+
+type lspTextDocumentIdentifier struct {
+	URI string `json:"uri"`
+}
+
+type lspTextDocumentItem struct {
+	URI        string `json:"uri"`
+	LanguageID string `json:"languageId"`
+	Version    int    `json:"version"`
+	Text       string `json:"text"`
+}
+
+type lspTextDocumentContentChangeEvent struct {
+	Range *lspRange `json:"range,omitempty"`
+	Text  string    `json:"text"`
+}
+
+type lspDidOpenTextDocumentParams struct {
+	TextDocument lspTextDocumentItem `json:"textDocument"`
+}
+
+type lspDidChangeTextDocumentParams struct {
+	TextDocument   lspTextDocumentIdentifier           `json:"textDocument"`
+	ContentChanges []lspTextDocumentContentChangeEvent `json:"contentChanges"`
+}
+
+type lspTextDocumentParams struct {
+	TextDocument lspTextDocumentIdentifier `json:"textDocument"`
+}
+
+type lspTextDocumentPositionParams struct {
+	TextDocument lspTextDocumentIdentifier `json:"textDocument"`
+	Position     lspPosition               `json:"position"`
+}
+
+type lspPublishDiagnosticsParams struct {
+	URI         string          `json:"uri"`
+	Diagnostics []lspDiagnostic `json:"diagnostics"`
+}
+
+type lspDiagnostic struct {
+	Range    lspRange `json:"range"`
+	Severity int      `json:"severity"`
+	Source   string   `json:"source"`
+	Message  string   `json:"message"`
+}
+
+type lspHover struct {
+	Contents lspMarkupContent `json:"contents"`
+	Range    *lspRange        `json:"range,omitempty"`
+}
+
+type lspMarkupContent struct {
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+type lspFoldingRange struct {
+	StartLine int    `json:"startLine"`
+	EndLine   int    `json:"endLine"`
+	Kind      string `json:"kind,omitempty"`
+}
+
+type lspDocumentHighlight struct {
+	Range lspRange `json:"range"`
+	Kind  int      `json:"kind"`
+}
+
+type lspDocumentLink struct {
+	Range   lspRange `json:"range"`
+	Target  string   `json:"target"`
+	Tooltip string   `json:"tooltip,omitempty"`
+}
+
+type lspTextEdit struct {
+	Range   lspRange `json:"range"`
+	NewText string   `json:"newText"`
+}
+
+type lspInitializeResult struct {
+	Capabilities lspServerCapabilities `json:"capabilities"`
+	ServerInfo   lspServerInfo         `json:"serverInfo"`
+}
+
+type lspServerCapabilities struct {
+	TextDocumentSync           int                    `json:"textDocumentSync"`
+	HoverProvider              bool                   `json:"hoverProvider"`
+	FoldingRangeProvider       bool                   `json:"foldingRangeProvider"`
+	DocumentHighlightProvider  bool                   `json:"documentHighlightProvider"`
+	DocumentLinkProvider       lspDocumentLinkOptions `json:"documentLinkProvider"`
+	DefinitionProvider         bool                   `json:"definitionProvider"`
+	DocumentFormattingProvider bool                   `json:"documentFormattingProvider"`
+}
+
+type lspDocumentLinkOptions struct {
+	ResolveProvider bool `json:"resolveProvider"`
+}
+
+type lspServerInfo struct {
+	Name string `json:"name"`
+}
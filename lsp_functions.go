@@ -0,0 +1,122 @@
+package dorklang
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+func ServeLanguageServer(input io.Reader, output io.Writer) (err error) {
+	server := &lspServer{
+		reader:    bufio.NewReader(input),
+		writer:    output,
+		documents: make(map[string]*lspDocument),
+	}
+
+	err = server.serve()
+
+	return
+}
+
+func readLSPMessage(reader *bufio.Reader) (content []byte, err error) {
+	contentLength := -1
+
+	for {
+		var line string
+
+		line, err = reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+
+		name, value, found := strings.Cut(line, ":")
+		if !found || !strings.EqualFold(strings.TrimSpace(name), lspContentLengthHeader) {
+			continue
+		}
+
+		contentLength, err = strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return
+		}
+	}
+
+	if contentLength < 0 || contentLength > lspMessageContentLengthMaxLength {
+		err = fmt.Errorf("invalid %s header: %d", lspContentLengthHeader, contentLength)
+		return
+	}
+
+	content = make([]byte, contentLength)
+	_, err = io.ReadFull(reader, content)
+
+	return
+}
+
+func writeLSPMessage(writer io.Writer, message any) (err error) {
+	content, err := json.Marshal(message)
+	if err != nil {
+		return
+	}
+
+	if _, err = fmt.Fprintf(writer, "%s: %d\r\n\r\n", lspContentLengthHeader, len(content)); err != nil {
+		return
+	}
+
+	_, err = writer.Write(content)
+
+	return
+}
+
+func newLSPDocument(uri string, text []byte) *lspDocument {
+	document := &lspDocument{
+		uri:      uri,
+		filePath: lspFilePathFromURI(uri),
+	}
+
+	document.setText(text)
+
+	return document
+}
+
+func lspFilePathFromURI(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != lspURIScheme {
+		return ""
+	}
+
+	return filepath.FromSlash(u.Path)
+}
+
+func lspURIFromFilePath(filePath string) string {
+	u := url.URL{
+		Scheme: lspURIScheme,
+		Path:   filepath.ToSlash(filePath),
+	}
+
+	return u.String()
+}
+
+func lspCharacterCount(text []byte) (count int) {
+	for len(text) > 0 {
+		r, size := utf8.DecodeRune(text)
+		text = text[size:]
+
+		if r >= 0x10000 {
+			count += 2
+		} else {
+			count++
+		}
+	}
+
+	return
+}
@@ -0,0 +1,675 @@
+package dorklang
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+func (server *lspServer) serve() (err error) {
+	for !server.exited {
+		var content []byte
+
+		content, err = readLSPMessage(server.reader)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = nil
+			}
+
+			return
+		}
+
+		var request lspRequest
+
+		if err2 := json.Unmarshal(content, &request); err2 != nil {
+			if err = server.respondError(nil, lspErrorCodeParse, err2.Error()); err != nil {
+				return
+			}
+
+			continue
+		}
+
+		if err = server.handle(request); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (server *lspServer) handle(request lspRequest) (err error) {
+	var result any
+	var errResponse *lspResponseError
+
+	switch request.Method {
+	case "initialize":
+		{
+			server.initialized = true
+
+			result = lspInitializeResult{
+				Capabilities: lspServerCapabilities{
+					TextDocumentSync:           lspTextDocumentSyncFull,
+					HoverProvider:              true,
+					FoldingRangeProvider:       true,
+					DocumentHighlightProvider:  true,
+					DefinitionProvider:         true,
+					DocumentFormattingProvider: true,
+				},
+				ServerInfo: lspServerInfo{
+					Name: lspServerName,
+				},
+			}
+		}
+	case "shutdown":
+		server.shutdown = true
+	case "exit":
+		server.exited = true
+	case "textDocument/didOpen":
+		{
+			var params lspDidOpenTextDocumentParams
+			if errResponse = lspDecodeParams(request.Params, &params); errResponse != nil {
+				break
+			}
+
+			document := newLSPDocument(params.TextDocument.URI, []byte(params.TextDocument.Text))
+			server.documents[document.uri] = document
+
+			err = server.publishDiagnostics(document)
+		}
+	case "textDocument/didChange":
+		{
+			var params lspDidChangeTextDocumentParams
+			if errResponse = lspDecodeParams(request.Params, &params); errResponse != nil {
+				break
+			}
+
+			document, found := server.documents[params.TextDocument.URI]
+			if !found {
+				document = newLSPDocument(params.TextDocument.URI, nil)
+				server.documents[document.uri] = document
+			}
+
+			for _, change := range params.ContentChanges {
+				document.applyChange(change)
+			}
+
+			err = server.publishDiagnostics(document)
+		}
+	case "textDocument/didSave":
+		{
+			var params lspTextDocumentParams
+			if errResponse = lspDecodeParams(request.Params, &params); errResponse != nil {
+				break
+			}
+
+			if document, found := server.documents[params.TextDocument.URI]; found {
+				err = server.publishDiagnostics(document)
+			}
+		}
+	case "textDocument/didClose":
+		{
+			var params lspTextDocumentParams
+			if errResponse = lspDecodeParams(request.Params, &params); errResponse != nil {
+				break
+			}
+
+			delete(server.documents, params.TextDocument.URI)
+
+			err = writeLSPMessage(server.writer, lspNotification{
+				JSONRPC: lspJSONRPCVersion,
+				Method:  "textDocument/publishDiagnostics",
+				Params: lspPublishDiagnosticsParams{
+					URI:         params.TextDocument.URI,
+					Diagnostics: []lspDiagnostic{},
+				},
+			})
+		}
+	case "textDocument/hover":
+		{
+			var params lspTextDocumentPositionParams
+			if errResponse = lspDecodeParams(request.Params, &params); errResponse != nil {
+				break
+			}
+
+			if document, found := server.documents[params.TextDocument.URI]; found {
+				result = document.hover(params.Position)
+			}
+		}
+	case "textDocument/foldingRange":
+		{
+			var params lspTextDocumentParams
+			if errResponse = lspDecodeParams(request.Params, &params); errResponse != nil {
+				break
+			}
+
+			if document, found := server.documents[params.TextDocument.URI]; found {
+				result = document.foldingRanges()
+			}
+		}
+	case "textDocument/documentHighlight":
+		{
+			var params lspTextDocumentPositionParams
+			if errResponse = lspDecodeParams(request.Params, &params); errResponse != nil {
+				break
+			}
+
+			if document, found := server.documents[params.TextDocument.URI]; found {
+				result = document.highlights(params.Position)
+			}
+		}
+	case "textDocument/documentLink":
+		{
+			var params lspTextDocumentParams
+			if errResponse = lspDecodeParams(request.Params, &params); errResponse != nil {
+				break
+			}
+
+			if document, found := server.documents[params.TextDocument.URI]; found {
+				result = document.links()
+			}
+		}
+	case "textDocument/definition":
+		{
+			var params lspTextDocumentPositionParams
+			if errResponse = lspDecodeParams(request.Params, &params); errResponse != nil {
+				break
+			}
+
+			if document, found := server.documents[params.TextDocument.URI]; found {
+				result = document.definition(params.Position)
+			}
+		}
+	case "textDocument/formatting":
+		{
+			var params lspTextDocumentParams
+			if errResponse = lspDecodeParams(request.Params, &params); errResponse != nil {
+				break
+			}
+
+			if document, found := server.documents[params.TextDocument.URI]; found {
+				var err2 error
+
+				result, err2 = document.formattingEdits()
+				if err2 != nil {
+					errResponse = &lspResponseError{
+						Code:    lspErrorCodeRequestFailed,
+						Message: err2.Error(),
+					}
+				}
+			}
+		}
+	default:
+		if request.ID != nil {
+			errResponse = &lspResponseError{
+				Code:    lspErrorCodeMethodNotFound,
+				Message: fmt.Sprintf("method %q is not supported", request.Method),
+			}
+		}
+	}
+
+	if err != nil || request.ID == nil {
+		return
+	}
+
+	if errResponse != nil {
+		err = server.respondError(request.ID, errResponse.Code, errResponse.Message)
+		return
+	}
+
+	err = server.respond(request.ID, result)
+
+	return
+}
+
+func (server *lspServer) respond(id *json.RawMessage, result any) (err error) {
+	content, err := json.Marshal(result)
+	if err != nil {
+		return
+	}
+
+	err = writeLSPMessage(server.writer, lspResponse{
+		JSONRPC: lspJSONRPCVersion,
+		ID:      id,
+		Result:  content,
+	})
+
+	return
+}
+
+func (server *lspServer) respondError(id *json.RawMessage, code int, message string) (err error) {
+	err = writeLSPMessage(server.writer, lspResponse{
+		JSONRPC: lspJSONRPCVersion,
+		ID:      id,
+		Error: &lspResponseError{
+			Code:    code,
+			Message: message,
+		},
+	})
+
+	return
+}
+
+func (server *lspServer) publishDiagnostics(document *lspDocument) (err error) {
+	err = writeLSPMessage(server.writer, lspNotification{
+		JSONRPC: lspJSONRPCVersion,
+		Method:  "textDocument/publishDiagnostics",
+		Params: lspPublishDiagnosticsParams{
+			URI:         document.uri,
+			Diagnostics: document.diagnostics(),
+		},
+	})
+
+	return
+}
+
+func lspDecodeParams(params json.RawMessage, v any) *lspResponseError {
+	if err := json.Unmarshal(params, v); err != nil {
+		return &lspResponseError{
+			Code:    lspErrorCodeInvalidParams,
+			Message: err.Error(),
+		}
+	}
+
+	return nil
+}
+
+func (document *lspDocument) setText(text []byte) {
+	document.text = text
+	document.lineOffsets = []int{0}
+
+	for i, b := range text {
+		if b == '\n' {
+			document.lineOffsets = append(document.lineOffsets, i+1)
+		}
+	}
+}
+
+func (document *lspDocument) applyChange(change lspTextDocumentContentChangeEvent) {
+	if change.Range == nil {
+		document.setText([]byte(change.Text))
+		return
+	}
+
+	start := document.offsetAt(change.Range.Start)
+	end := document.offsetAt(change.Range.End)
+	if end < start {
+		start, end = end, start
+	}
+
+	text := make([]byte, 0, len(document.text)-(end-start)+len(change.Text))
+	text = append(text, document.text[:start]...)
+	text = append(text, change.Text...)
+	text = append(text, document.text[end:]...)
+
+	document.setText(text)
+}
+
+func (document *lspDocument) positionAt(offset int) lspPosition {
+	if offset < 0 {
+		offset = 0
+	} else if offset > len(document.text) {
+		offset = len(document.text)
+	}
+
+	line := sort.Search(len(document.lineOffsets), func(i int) bool {
+		return document.lineOffsets[i] > offset
+	}) - 1
+
+	return lspPosition{
+		Line:      line,
+		Character: lspCharacterCount(document.text[document.lineOffsets[line]:offset]),
+	}
+}
+
+func (document *lspDocument) offsetAt(position lspPosition) int {
+	if position.Line < 0 {
+		return 0
+	}
+
+	if position.Line >= len(document.lineOffsets) {
+		return len(document.text)
+	}
+
+	offset := document.lineOffsets[position.Line]
+
+	for character := 0; character < position.Character && offset < len(document.text) && document.text[offset] != '\n'; {
+		_, size := utf8.DecodeRune(document.text[offset:])
+
+		character += lspCharacterCount(document.text[offset : offset+size])
+		offset += size
+	}
+
+	return offset
+}
+
+func (document *lspDocument) rangeOf(span sourceSpan) lspRange {
+	return lspRange{
+		Start: document.positionAt(span.start.Offset),
+		End:   document.positionAt(span.end.Offset),
+	}
+}
+
+func (document *lspDocument) dir() string {
+	if document.filePath == "" {
+		return ""
+	}
+
+	return filepath.Dir(document.filePath)
+}
+
+func (document *lspDocument) options() InterpretCodeOptions {
+	options := InterpretCodeDefaultOptions.Clone()
+
+	options.WorkingDir = document.dir()
+	options.FilePath = document.filePath
+
+	return options
+}
+
+// the tokens before an error are still returned, so a document that is being edited keeps its hovers, folding ranges and links
+func (document *lspDocument) tokens() (tokens tokenCollection, err error) {
+	tokens, err = produceTokens(document.text, document.filePath)
+
+	return
+}
+
+func (document *lspDocument) resolvePath(path string) string {
+	if filepath.IsAbs(path) || document.filePath == "" {
+		return path
+	}
+
+	return filepath.Join(document.dir(), path)
+}
+
+func (document *lspDocument) diagnostics() (diagnostics []lspDiagnostic) {
+	diagnostics = []lspDiagnostic{}
+
+	if _, err := document.tokens(); err != nil {
+		diagnostic := lspDiagnostic{
+			Severity: lspDiagnosticSeverityError,
+			Source:   lspDiagnosticSource,
+			Message:  err.Error(),
+		}
+
+		var positionErr *PositionError
+		if errors.As(err, &positionErr) {
+			diagnostic.Message = positionErr.Err.Error()
+			diagnostic.Range = lspRange{
+				Start: document.positionAt(positionErr.Position.Offset),
+				End:   document.positionAt(positionErr.Position.Offset + 1),
+			}
+		}
+
+		diagnostics = append(diagnostics, diagnostic)
+
+		return
+	}
+
+	vetDiagnostics, err := VetCode(document.text, document.options())
+	if err != nil {
+		diagnostics = append(diagnostics, lspDiagnostic{
+			Severity: lspDiagnosticSeverityError,
+			Source:   lspDiagnosticSource,
+			Message:  err.Error(),
+		})
+
+		return
+	}
+
+	for _, vetDiagnostic := range vetDiagnostics {
+		if vetDiagnostic.Start.FilePath != document.filePath {
+			continue
+		}
+
+		diagnostics = append(diagnostics, lspDiagnostic{
+			Range:    document.rangeOf(sourceSpan{start: vetDiagnostic.Start, end: vetDiagnostic.End}),
+			Severity: lspDiagnosticSeverityWarning,
+			Source:   lspDiagnosticSource,
+			Message:  vetDiagnostic.Message,
+		})
+	}
+
+	return
+}
+
+func (document *lspDocument) tokenAt(tokens tokenCollection, position lspPosition) (index int, found bool) {
+	offset := document.offsetAt(position)
+
+	index, found = tokens.tokenAt(offset)
+	if (!found || tokens[index].lex == separatorLexeme) && offset > 0 {
+		index, found = tokens.tokenAt(offset - 1)
+	}
+
+	if found && tokens[index].lex == separatorLexeme {
+		found = false
+	}
+
+	return
+}
+
+func (document *lspDocument) hover(position lspPosition) *lspHover {
+	tokens, _ := document.tokens()
+
+	index, found := document.tokenAt(tokens, position)
+	if !found {
+		return nil
+	}
+
+	t := tokens[index]
+
+	var builder strings.Builder
+
+	switch t.lex {
+	case filePathLexeme:
+		{
+			filePath := document.resolvePath(string(t.data))
+
+			builder.WriteString("**`" + string(t.data) + "`**\n\n")
+
+			if filepath.Ext(filePath) == FileExtensionForCode {
+				builder.WriteString("Runs the commands in `" + filePath + "`.")
+			} else {
+				builder.WriteString("Pushes the contents of `" + filePath + "` onto the **current stack**.")
+			}
+
+			if _, err := os.Stat(filePath); err != nil {
+				builder.WriteString("\n\nThis file does not exist.")
+			}
+		}
+	default:
+		{
+			description := t.lex.description()
+			if description == "" {
+				return nil
+			}
+
+			builder.WriteString("**`" + t.text() + "`** " + t.lex.name() + "\n\n" + description)
+		}
+	}
+
+	if point, found := document.stackDepthPoint(t); found {
+		indices := make([]string, len(point.StackIndices))
+		for i, index := range point.StackIndices {
+			indices[i] = fmt.Sprint(index)
+		}
+
+		fmt.Fprintf(&builder, "\n\n---\n\nCurrent stack: %s\n\n", strings.Join(indices, " or "))
+
+		for i := range point.Before {
+			fmt.Fprintf(&builder, "- stack %d: %s → %s\n", i, point.Before[i], point.After[i])
+		}
+	}
+
+	tokenRange := document.rangeOf(t.span)
+
+	return &lspHover{
+		Contents: lspMarkupContent{
+			Kind:  lspMarkupKindMarkdown,
+			Value: builder.String(),
+		},
+		Range: &tokenRange,
+	}
+}
+
+func (document *lspDocument) stackDepthPoint(t token) (point StackDepthPoint, found bool) {
+	report, err := AnalyseStackDepth(document.text, document.options())
+	if err != nil {
+		options := document.options()
+		options.SkipClean = true
+
+		report, err = AnalyseStackDepth(document.text, options)
+		if err != nil {
+			return
+		}
+	}
+
+	for _, point = range report.Points {
+		if point.Start.FilePath == document.filePath && point.Start.Offset == t.span.start.Offset {
+			found = true
+			return
+		}
+	}
+
+	return
+}
+
+func (document *lspDocument) foldingRanges() (ranges []lspFoldingRange) {
+	ranges = []lspFoldingRange{}
+
+	tokens, _ := document.tokens()
+
+	pairs := tokens.sectionPairs()
+
+	for i, t := range tokens {
+		j, found := pairs[i]
+		if !found || j < i {
+			continue
+		}
+
+		startLine := document.positionAt(t.span.start.Offset).Line
+		endLine := document.positionAt(tokens[j].span.start.Offset).Line
+
+		if endLine <= startLine {
+			continue
+		}
+
+		kind := lspFoldingRangeKindRegion
+		if t.lex == startCommentSectionLexeme {
+			kind = lspFoldingRangeKindComment
+		}
+
+		ranges = append(ranges, lspFoldingRange{
+			StartLine: startLine,
+			EndLine:   endLine,
+			Kind:      kind,
+		})
+	}
+
+	return
+}
+
+func (document *lspDocument) highlights(position lspPosition) (highlights []lspDocumentHighlight) {
+	highlights = []lspDocumentHighlight{}
+
+	tokens, _ := document.tokens()
+
+	pairs := tokens.sectionPairs()
+	offset := document.offsetAt(position)
+
+	var index, index2 int
+	found := false
+
+	for _, offset := range []int{offset, offset - 1} {
+		if index, found = tokens.tokenAt(offset); found {
+			if index2, found = pairs[index]; found {
+				break
+			}
+		}
+	}
+
+	if !found {
+		return
+	}
+
+	for _, i := range []int{index, index2} {
+		highlights = append(highlights, lspDocumentHighlight{
+			Range: document.rangeOf(tokens[i].span),
+			Kind:  lspDocumentHighlightKindText,
+		})
+	}
+
+	return
+}
+
+func (document *lspDocument) links() (links []lspDocumentLink) {
+	links = []lspDocumentLink{}
+
+	tokens, _ := document.tokens()
+
+	for _, t := range tokens {
+		if t.lex != filePathLexeme || len(t.data) == 0 {
+			continue
+		}
+
+		filePath := document.resolvePath(string(t.data))
+		if _, err := os.Stat(filePath); err != nil || !filepath.IsAbs(filePath) {
+			continue
+		}
+
+		links = append(links, lspDocumentLink{
+			Range:   document.rangeOf(t.span),
+			Target:  lspURIFromFilePath(filePath),
+			Tooltip: filePath,
+		})
+	}
+
+	return
+}
+
+func (document *lspDocument) definition(position lspPosition) *lspLocation {
+	tokens, _ := document.tokens()
+
+	index, found := document.tokenAt(tokens, position)
+	if !found || tokens[index].lex != filePathLexeme {
+		return nil
+	}
+
+	filePath := document.resolvePath(string(tokens[index].data))
+	if _, err := os.Stat(filePath); err != nil || !filepath.IsAbs(filePath) {
+		return nil
+	}
+
+	return &lspLocation{
+		URI: lspURIFromFilePath(filePath),
+	}
+}
+
+func (document *lspDocument) formattingEdits() (edits []lspTextEdit, err error) {
+	edits = []lspTextEdit{}
+
+	output, err := FormatCode(document.text)
+	if err != nil {
+		return
+	}
+
+	if bytes.Equal(output, document.text) {
+		return
+	}
+
+	edits = append(edits, lspTextEdit{
+		Range: lspRange{
+			Start: document.positionAt(0),
+			End:   document.positionAt(len(document.text)),
+		},
+		NewText: string(output),
+	})
+
+	return
+}
@@ -0,0 +1,43 @@
+package dorklang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLSPDocumentKeepsTokensBeforeError(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(dir, "included.dork"), []byte("+"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	document := &lspDocument{filePath: filepath.Join(dir, "main.dork")}
+	document.setText([]byte("(\n+\n)\n{{ included.dork }}\n+ %y"))
+
+	diagnostics := document.diagnostics()
+	if len(diagnostics) != 1 || diagnostics[0].Range.Start != (lspPosition{Line: 4, Character: 3}) {
+		t.Errorf("expected one diagnostic at the unrecognized command, got %+v", diagnostics)
+	}
+
+	if hover := document.hover(lspPosition{Line: 1, Character: 0}); hover == nil {
+		t.Error("expected a hover before the error")
+	}
+
+	if ranges := document.foldingRanges(); len(ranges) != 1 || ranges[0].StartLine != 0 || ranges[0].EndLine != 2 {
+		t.Errorf("expected the folding range before the error, got %+v", ranges)
+	}
+
+	if highlights := document.highlights(lspPosition{Line: 0, Character: 0}); len(highlights) != 2 {
+		t.Errorf("expected the matching brackets to be highlighted, got %+v", highlights)
+	}
+
+	if links := document.links(); len(links) != 1 {
+		t.Errorf("expected the link before the error, got %+v", links)
+	}
+
+	if location := document.definition(lspPosition{Line: 3, Character: 4}); location == nil {
+		t.Error("expected the definition before the error")
+	}
+}
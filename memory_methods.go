@@ -39,3 +39,40 @@ func (collection memoryCellCollection) Reverse() {
 		collection[i], collection[j] = collection[j], collection[i]
 	}
 }
+
+func (collection memoryCellCollection) Peek(depth memoryCell) (value memoryCell, err error) {
+	if depth >= memoryCellFromIntegerConstraint(len(collection)) {
+		err = ErrTreeSaveStackEmpty
+		return
+	}
+
+	value = collection[len(collection)-1-int(depth)]
+
+	return
+}
+
+func (collection memoryCellCollection) Pick(depth memoryCell) (output memoryCellCollection, err error) {
+	value, err := collection.Peek(depth)
+	if err != nil {
+		return
+	}
+
+	output = append(collection, value)
+
+	return
+}
+
+func (collection memoryCellCollection) Roll(depth memoryCell) (err error) {
+	if depth >= memoryCellFromIntegerConstraint(len(collection)) {
+		err = ErrTreeSaveStackEmpty
+		return
+	}
+
+	i := len(collection) - 1 - int(depth)
+	value := collection[i]
+
+	copy(collection[i:], collection[i+1:])
+	collection[len(collection)-1] = value
+
+	return
+}
@@ -0,0 +1,91 @@
+package dorklang
+
+import "strings"
+
+func MinifyCode(input []byte, clean bool) (output []byte, err error) {
+	tokens, err := produceTokens(input, "")
+	if err != nil {
+		return
+	}
+
+	if clean {
+		simplifyTokens(tokens)
+	}
+
+	var builder strings.Builder
+
+	previousLexeme := invalidLexeme
+	var previousData []byte
+
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+
+		var text string
+
+		switch t.lex {
+		case startProgramLexeme,
+			endProgramLexeme,
+			separatorLexeme,
+			emptyLexeme:
+			continue
+		case startCommentSectionLexeme:
+			i = tokens.matchingSectionEnd(i)
+			continue
+		case startReadFileSectionLexeme:
+			{
+				var sectionBuilder strings.Builder
+
+				j := tokens.matchingSectionEnd(i)
+
+				sectionBuilder.WriteString(startReadFileSectionLexeme.sourceText())
+
+				for k, pathCount := i+1, 0; k < j; k++ {
+					if tokens[k].lex != filePathLexeme {
+						continue
+					}
+
+					if pathCount > 0 {
+						sectionBuilder.WriteByte(' ')
+					}
+
+					sectionBuilder.Write(tokens[k].data)
+					pathCount++
+				}
+
+				sectionBuilder.WriteString(endReadFileSectionLexeme.sourceText())
+
+				text = sectionBuilder.String()
+				i = j
+			}
+		default:
+			text = t.text()
+		}
+
+		if text == "" {
+			continue
+		}
+
+		if lexemeAbsorbsText(previousLexeme, previousData, text) {
+			builder.WriteByte(' ')
+		}
+
+		builder.WriteString(text)
+
+		previousLexeme = t.lex
+		previousData = t.data
+	}
+
+	output = []byte(builder.String())
+
+	outputTokens, err := produceTokens(output, "")
+	if err != nil {
+		return
+	}
+
+	if !tokens.withoutComments().equivalent(outputTokens) {
+		err = ErrMinifyTokensChanged
+		return
+	}
+
+	return
+}
@@ -0,0 +1,34 @@
+package dorklang
+
+import "testing"
+
+func TestMinifyCodeSeparators(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"26 d", "26d"},
+		{"26 7", "26 7"},
+		{"0 x", "0 x"},
+		{"0x1a d", "0x1a d"},
+		{"0x1a g", "0x1ag"},
+		{"0 d", "0d"},
+		{"7 b", "7b"},
+		{"+ +", "+ +"},
+		{"&fib d", "&fib d"},
+		{"&fib :", "&fib:"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			output, err := MinifyCode([]byte(testCase.input), false)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(output) != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, output)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package dorklang
+
+type Position struct {
+	FilePath string `json:"filePath,omitempty"`
+	Offset   int    `json:"offset"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+}
+
+type sourceSpan struct {
+	start Position
+	end   Position
+}
+
+type PositionError struct {
+	Position Position
+	Err      error
+}
@@ -0,0 +1,37 @@
+package dorklang
+
+import (
+	"strconv"
+	"strings"
+)
+
+func (position Position) IsValid() bool {
+	return position.Line > 0
+}
+
+func (position Position) String() string {
+	var builder strings.Builder
+
+	if position.FilePath != "" {
+		builder.WriteString(position.FilePath)
+		builder.WriteByte(':')
+	}
+
+	builder.WriteString(strconv.Itoa(position.Line))
+	builder.WriteByte(':')
+	builder.WriteString(strconv.Itoa(position.Column))
+
+	return builder.String()
+}
+
+func (span sourceSpan) contains(offset int) bool {
+	return offset >= span.start.Offset && offset < span.end.Offset
+}
+
+func (err *PositionError) Error() string {
+	return err.Position.String() + ": " + err.Err.Error()
+}
+
+func (err *PositionError) Unwrap() error {
+	return err.Err
+}
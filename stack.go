@@ -0,0 +1,58 @@
+package dorklang
+
+const (
+	stackAnalysisStackCount = 2
+)
+
+type StackRange struct {
+	Min          int  `json:"min"`
+	Max          int  `json:"max"`
+	MaxUnbounded bool `json:"maxUnbounded,omitempty"`
+}
+
+type StackDepthPoint struct {
+	Start        Position                            `json:"start"`
+	End          Position                            `json:"end"`
+	Command      string                              `json:"command"`
+	StackIndices []int                               `json:"stackIndices"`
+	Before       [stackAnalysisStackCount]StackRange `json:"before"`
+	After        [stackAnalysisStackCount]StackRange `json:"after"`
+}
+
+type StackDepthReport struct {
+	Points      []StackDepthPoint    `json:"points"`
+	Diagnostics DiagnosticCollection `json:"diagnostics"`
+}
+
+type stackPointKey struct {
+	filePath string
+	offset   int
+	lexeme   lexeme
+}
+
+type stackAnalysis struct {
+	options         InterpretCodeOptions
+	dir             string
+	recording       bool
+	visiting        map[string]bool
+	calling         map[string]bool
+	loops           []*stackLoopJumps
+	points          map[stackPointKey]*StackDepthPoint
+	diagnostics     DiagnosticCollection
+	diagnosticsSeen map[Diagnostic]bool
+}
+
+// the states in which break and continue commands leave the body of a loop
+type stackLoopJumps struct {
+	breakState    stackState
+	breakFound    bool
+	continueState stackState
+	continueFound bool
+}
+
+type stackState struct {
+	value        memoryCell
+	valueKnown   bool
+	stackIndices [stackAnalysisStackCount]bool
+	stacks       [stackAnalysisStackCount]StackRange
+}
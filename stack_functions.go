@@ -0,0 +1,91 @@
+package dorklang
+
+import (
+	"os"
+	"sort"
+)
+
+func AnalyseStackDepth(input []byte, options InterpretCodeOptions) (report StackDepthReport, err error) {
+	err = withWorkingDir(options.WorkingDir, func() (err error) {
+		tokens, err := produceCleanTokens(input, options)
+		if err != nil {
+			return
+		}
+
+		tr, err := produceTree(tokens, options)
+		if err != nil {
+			return
+		}
+
+		analysis, err := analyseStackDepthTree(tr, options)
+		if err != nil {
+			return
+		}
+
+		report = analysis.report()
+
+		return
+	})
+
+	return
+}
+
+func analyseStackDepthTree(tr *tree, options InterpretCodeOptions) (analysis *stackAnalysis, err error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return
+	}
+
+	analysis = newStackAnalysis(options, dir)
+
+	if options.FilePath != "" {
+		analysis.visiting[options.FilePath] = true
+	}
+
+	analysis.analyseNodes(tr.rootNode.childNodes, newStackState())
+
+	return
+}
+
+func newStackAnalysis(options InterpretCodeOptions, dir string) *stackAnalysis {
+	return &stackAnalysis{
+		options:         options,
+		dir:             dir,
+		recording:       true,
+		visiting:        make(map[string]bool),
+		calling:         make(map[string]bool),
+		points:          make(map[stackPointKey]*StackDepthPoint),
+		diagnosticsSeen: make(map[Diagnostic]bool),
+	}
+}
+
+func newStackState() stackState {
+	return stackState{
+		valueKnown:   true,
+		stackIndices: [stackAnalysisStackCount]bool{true, false},
+	}
+}
+
+func newStackRange(min, max int) StackRange {
+	return StackRange{
+		Min: min,
+		Max: max,
+	}
+}
+
+func newUnboundedStackRange(min int) StackRange {
+	return StackRange{
+		Min:          min,
+		MaxUnbounded: true,
+	}
+}
+
+func sortStackDepthPoints(points []StackDepthPoint) {
+	sort.Slice(points, func(i, j int) bool {
+		if points[i].Start.FilePath != points[j].Start.FilePath {
+			return points[i].Start.FilePath < points[j].Start.FilePath
+		}
+
+		return points[i].Start.Offset < points[j].Start.Offset
+	})
+}
@@ -0,0 +1,879 @@
+package dorklang
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+func (stackRange StackRange) String() string {
+	if stackRange.MaxUnbounded {
+		return strconv.Itoa(stackRange.Min) + ".."
+	}
+
+	if stackRange.Min == stackRange.Max {
+		return strconv.Itoa(stackRange.Min)
+	}
+
+	return strconv.Itoa(stackRange.Min) + ".." + strconv.Itoa(stackRange.Max)
+}
+
+func (report StackDepthReport) WriteText(output io.Writer) (err error) {
+	writer := tabwriter.NewWriter(output, 0, 8, 2, ' ', 0)
+
+	for _, point := range report.Points {
+		stackNames := make([]string, len(point.StackIndices))
+		for i, index := range point.StackIndices {
+			stackNames[i] = strconv.Itoa(index)
+		}
+
+		if _, err = fmt.Fprintf(
+			writer,
+			"%s\t%s\tcurrent %s\tstack 0: %s -> %s\tstack 1: %s -> %s\n",
+			point.Start,
+			point.Command,
+			strings.Join(stackNames, "/"),
+			point.Before[0],
+			point.After[0],
+			point.Before[1],
+			point.After[1],
+		); err != nil {
+			return
+		}
+	}
+
+	if err = writer.Flush(); err != nil {
+		return
+	}
+
+	for _, diagnostic := range report.Diagnostics {
+		if _, err = fmt.Fprintln(output, diagnostic.String()); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (report StackDepthReport) WriteJSON(output io.Writer) (err error) {
+	encoder := json.NewEncoder(output)
+	encoder.SetIndent("", "\t")
+
+	err = encoder.Encode(report)
+
+	return
+}
+
+func (stackRange StackRange) join(other StackRange) (output StackRange) {
+	output = stackRange
+
+	if other.Min < output.Min {
+		output.Min = other.Min
+	}
+
+	if other.MaxUnbounded || output.MaxUnbounded {
+		output.Max = 0
+		output.MaxUnbounded = true
+	} else if other.Max > output.Max {
+		output.Max = other.Max
+	}
+
+	return
+}
+
+func (stackRange StackRange) widen(next StackRange) (output StackRange) {
+	output = stackRange
+
+	if next.Min < output.Min {
+		output.Min = 0
+	}
+
+	if next.MaxUnbounded || (!output.MaxUnbounded && next.Max > output.Max) {
+		output.Max = 0
+		output.MaxUnbounded = true
+	}
+
+	return
+}
+
+func (stackRange StackRange) add(count StackRange) (output StackRange) {
+	output.Min = stackRange.Min + count.Min
+
+	if stackRange.MaxUnbounded || count.MaxUnbounded {
+		output.MaxUnbounded = true
+	} else {
+		output.Max = stackRange.Max + count.Max
+	}
+
+	return
+}
+
+func (stackRange StackRange) subtract(count int) (output StackRange) {
+	output = stackRange
+
+	output.Min -= count
+	if output.Min < 0 {
+		output.Min = 0
+	}
+
+	if !output.MaxUnbounded {
+		output.Max -= count
+		if output.Max < 0 {
+			output.Max = 0
+		}
+	}
+
+	return
+}
+
+func (stackRange StackRange) clamp(limit int) (output StackRange) {
+	output = stackRange
+
+	if output.Min > limit {
+		output.Min = limit
+	}
+
+	if !output.MaxUnbounded && output.Max > limit {
+		output.Max = limit
+	}
+
+	return
+}
+
+func (state stackState) join(other stackState) (output stackState) {
+	output = state
+
+	if !other.valueKnown || other.value != state.value {
+		output.valueKnown = false
+	}
+
+	for i := range output.stacks {
+		output.stackIndices[i] = state.stackIndices[i] || other.stackIndices[i]
+		output.stacks[i] = state.stacks[i].join(other.stacks[i])
+	}
+
+	return
+}
+
+func (state stackState) widen(next stackState) (output stackState) {
+	output = next
+
+	for i := range output.stacks {
+		output.stacks[i] = state.stacks[i].widen(next.stacks[i])
+	}
+
+	return
+}
+
+func (state stackState) currentStackIndices() (indices []int) {
+	indices = make([]int, 0, len(state.stackIndices))
+
+	for i, possible := range state.stackIndices {
+		if possible {
+			indices = append(indices, i)
+		}
+	}
+
+	return
+}
+
+func (state *stackState) updateCurrentStacks(update func(StackRange) StackRange) {
+	indices := state.currentStackIndices()
+
+	for _, i := range indices {
+		if len(indices) == 1 {
+			state.stacks[i] = update(state.stacks[i])
+		} else {
+			state.stacks[i] = state.stacks[i].join(update(state.stacks[i]))
+		}
+	}
+}
+
+// the other stack of each possible current stack is updated, so both stacks are updated if either could be current
+func (state *stackState) updateOtherStacks(update func(StackRange) StackRange) {
+	indices := state.currentStackIndices()
+
+	for _, i := range indices {
+		otherIndex := stackAnalysisStackCount - 1 - i
+
+		if len(indices) == 1 {
+			state.stacks[otherIndex] = update(state.stacks[otherIndex])
+		} else {
+			state.stacks[otherIndex] = state.stacks[otherIndex].join(update(state.stacks[otherIndex]))
+		}
+	}
+}
+
+func (analysis *stackAnalysis) report() (report StackDepthReport) {
+	report.Points = make([]StackDepthPoint, 0, len(analysis.points))
+
+	for _, point := range analysis.points {
+		report.Points = append(report.Points, *point)
+	}
+
+	sortStackDepthPoints(report.Points)
+
+	report.Diagnostics = append(DiagnosticCollection{}, analysis.diagnostics...)
+	report.Diagnostics.Sort()
+
+	return
+}
+
+func (analysis *stackAnalysis) addDiagnostic(span sourceSpan, format string, args ...any) {
+	if !analysis.recording {
+		return
+	}
+
+	diagnostic := Diagnostic{
+		Start:   span.start,
+		End:     span.end,
+		Message: fmt.Sprintf(format, args...),
+	}
+
+	if analysis.diagnosticsSeen[diagnostic] {
+		return
+	}
+
+	analysis.diagnosticsSeen[diagnostic] = true
+	analysis.diagnostics = append(analysis.diagnostics, diagnostic)
+}
+
+func (analysis *stackAnalysis) record(node *terminalTreeNode, before, after stackState) {
+	if !analysis.recording || !node.span.start.IsValid() {
+		return
+	}
+
+	key := stackPointKey{
+		filePath: node.span.start.FilePath,
+		offset:   node.span.start.Offset,
+		lexeme:   node.lexeme,
+	}
+
+	point, found := analysis.points[key]
+	if !found {
+		command := node.lexeme.sourceText()
+		switch node.lexeme {
+		case filePathLexeme,
+			setLiteralLexeme:
+			command = string(node.data)
+		case pushStringLexeme:
+			command = stringLiteralStart + string(node.data) + stringLiteralEnd
+		case callProcedureLexeme:
+			command += string(node.data)
+		}
+
+		point = &StackDepthPoint{
+			Start:   node.span.start,
+			End:     node.span.end,
+			Command: command,
+			Before:  before.stacks,
+			After:   after.stacks,
+		}
+
+		analysis.points[key] = point
+	} else {
+		for i := range point.Before {
+			point.Before[i] = point.Before[i].join(before.stacks[i])
+			point.After[i] = point.After[i].join(after.stacks[i])
+		}
+	}
+
+	for _, i := range before.currentStackIndices() {
+		seen := false
+
+		for _, i2 := range point.StackIndices {
+			if i2 == i {
+				seen = true
+				break
+			}
+		}
+
+		if !seen {
+			point.StackIndices = append(point.StackIndices, i)
+		}
+	}
+}
+
+func (analysis *stackAnalysis) analyseNodes(nodes []treeNode, state stackState) stackState {
+	for _, node := range nodes {
+		switch node := node.(type) {
+		case *parentTreeNode:
+			state = analysis.analyseParentNode(node, state)
+		case *terminalTreeNode:
+			state = analysis.analyseTerminalNode(node, state)
+		}
+	}
+
+	return state
+}
+
+func (analysis *stackAnalysis) analyseParentNode(node *parentTreeNode, state stackState) stackState {
+	switch node.lexeme {
+	case startReadFileSectionLexeme:
+		return analysis.analyseNodes(node.childNodes, state)
+	case startProcedureSectionLexeme:
+		return state
+	case startAdditionSectionLexeme,
+		startSubtractionSectionLexeme,
+		startMultiplicationSectionLexeme,
+		startDivisionSectionLexeme,
+		startModuloSectionLexeme:
+		{
+			innerState := state
+			innerState.value = 0
+			innerState.valueKnown = true
+
+			innerState = analysis.analyseNodes(node.childNodes, innerState)
+
+			output := innerState
+			output.value = state.value
+			output.valueKnown = state.valueKnown && innerState.valueKnown
+
+			if output.valueKnown {
+				switch node.lexeme {
+				case startAdditionSectionLexeme:
+					output.value += innerState.value
+				case startSubtractionSectionLexeme:
+					output.value -= innerState.value
+				case startMultiplicationSectionLexeme:
+					output.value *= innerState.value
+				case startDivisionSectionLexeme:
+					if innerState.value == 0 {
+						output.valueKnown = false
+					} else {
+						output.value /= innerState.value
+					}
+				case startModuloSectionLexeme:
+					if innerState.value == 0 {
+						output.valueKnown = false
+					} else {
+						output.value %= innerState.value
+					}
+				}
+			}
+
+			return output
+		}
+	case startJumpIfPositiveSectionLexeme:
+		{
+			if state.valueKnown && state.value == 0 {
+				return state
+			}
+
+			output, jumps := analysis.analyseLoop(node, state, func(bodyState *stackState) {
+				bodyState.valueKnown = false
+			})
+			output.value = 0
+			output.valueKnown = true
+
+			if jumps.breakFound {
+				output = output.join(jumps.breakState)
+			}
+
+			return output
+		}
+	case startJumpIfZeroSectionLexeme:
+		{
+			if state.valueKnown && state.value != 0 {
+				return state
+			}
+
+			output, jumps := analysis.analyseLoop(node, state, func(bodyState *stackState) {
+				bodyState.value = 0
+				bodyState.valueKnown = true
+			})
+			output.valueKnown = false
+
+			if jumps.breakFound {
+				output = output.join(jumps.breakState)
+			}
+
+			return output
+		}
+	case startIfSectionLexeme,
+		startIfZeroSectionLexeme:
+		{
+			bodyNodes, elseNode := node.ifBranches()
+
+			bodyState := state
+			if node.lexeme == startIfZeroSectionLexeme {
+				bodyState.value = 0
+				bodyState.valueKnown = true
+			}
+
+			elseState := state
+			if node.lexeme == startIfSectionLexeme {
+				elseState.value = 0
+				elseState.valueKnown = true
+			}
+
+			if state.valueKnown {
+				if (state.value != 0) == (node.lexeme == startIfSectionLexeme) {
+					return analysis.analyseNodes(bodyNodes, state)
+				}
+
+				if elseNode == nil {
+					return state
+				}
+
+				return analysis.analyseNodes(elseNode.childNodes, state)
+			}
+
+			bodyState = analysis.analyseNodes(bodyNodes, bodyState)
+
+			if elseNode != nil {
+				elseState = analysis.analyseNodes(elseNode.childNodes, elseState)
+			}
+
+			return bodyState.join(elseState)
+		}
+	}
+
+	return state
+}
+
+func (analysis *stackAnalysis) analyseLoop(node *parentTreeNode, state stackState, enter func(*stackState)) (loopState stackState, jumps *stackLoopJumps) {
+	recording := analysis.recording
+	analysis.recording = false
+
+	jumps = &stackLoopJumps{}
+	analysis.loops = append(analysis.loops, jumps)
+	defer func() {
+		analysis.loops = analysis.loops[:len(analysis.loops)-1]
+	}()
+
+	loopState = state
+
+	for i := 0; ; i++ {
+		bodyState := loopState
+		enter(&bodyState)
+
+		bodyState = analysis.analyseNodes(node.childNodes, bodyState)
+
+		nextLoopState := loopState.join(bodyState)
+		if jumps.continueFound {
+			nextLoopState = nextLoopState.join(jumps.continueState)
+		}
+		if i > 0 {
+			nextLoopState = loopState.widen(nextLoopState)
+		}
+
+		if nextLoopState == loopState {
+			break
+		}
+
+		loopState = nextLoopState
+	}
+
+	analysis.recording = recording
+
+	if recording {
+		bodyState := loopState
+		enter(&bodyState)
+
+		analysis.analyseNodes(node.childNodes, bodyState)
+	}
+
+	return
+}
+
+func (jumps *stackLoopJumps) add(lexeme lexeme, state stackState) {
+	switch lexeme {
+	case breakLoopLexeme:
+		if jumps.breakFound {
+			state = jumps.breakState.join(state)
+		}
+
+		jumps.breakState = state
+		jumps.breakFound = true
+	case continueLoopLexeme:
+		if jumps.continueFound {
+			state = jumps.continueState.join(state)
+		}
+
+		jumps.continueState = state
+		jumps.continueFound = true
+	}
+}
+
+func (analysis *stackAnalysis) analyseTerminalNode(node *terminalTreeNode, state stackState) stackState {
+	before := state
+
+	switch {
+	case node.lexeme.isPureValueCommand():
+		if state.valueKnown {
+			if value, err := node.value(state.value); err == nil {
+				state.value = value
+			} else {
+				state.valueKnown = false
+			}
+		}
+	case node.lexeme.isConstantValueCommand():
+		if value, err := node.value(0); err == nil {
+			state.value = value
+			state.valueKnown = true
+		} else {
+			state.valueKnown = false
+		}
+	}
+
+	switch node.lexeme {
+	case setRandomByteLexeme,
+		setRandomMaxLexeme,
+		setSecondTimestampLexeme,
+		setNanosecondTimestampLexeme,
+		inputCharacterLexeme,
+		inputNumberLexeme,
+		hashStackOneByteLexeme,
+		hashStackEightByteLexeme,
+		addStackPairLexeme,
+		subtractStackPairLexeme,
+		multiplyStackPairLexeme,
+		divideStackPairLexeme,
+		moduloStackPairLexeme,
+		addStackWholeLexeme,
+		subtractStackWholeLexeme,
+		multiplyStackWholeLexeme,
+		divideStackWholeLexeme,
+		moduloStackWholeLexeme,
+		bitwiseAndStackPairLexeme,
+		bitwiseAndStackWholeLexeme,
+		bitwiseOrStackPairLexeme,
+		bitwiseOrStackWholeLexeme,
+		bitwiseXorStackPairLexeme,
+		bitwiseXorStackWholeLexeme,
+		shiftLeftStackLexeme,
+		shiftRightStackLexeme,
+		logicalAndStackPairLexeme,
+		logicalAndStackWholeLexeme,
+		logicalOrStackPairLexeme,
+		logicalOrStackWholeLexeme,
+		logicalXorStackPairLexeme,
+		logicalXorStackWholeLexeme,
+		equalStackPairLexeme,
+		notEqualStackPairLexeme,
+		lessStackPairLexeme,
+		greaterStackPairLexeme,
+		lessOrEqualStackPairLexeme,
+		greaterOrEqualStackPairLexeme,
+		popStackLastLexeme,
+		popStackRandomLexeme,
+		peekStackTopLexeme:
+		state.valueKnown = false
+	}
+
+	state = analysis.applyStackEffect(node, state)
+
+	switch node.lexeme {
+	case countStackLexeme:
+		{
+			indices := state.currentStackIndices()
+			stackRange := state.stacks[indices[0]]
+
+			state.valueKnown = len(indices) == 1 && !stackRange.MaxUnbounded && stackRange.Min == stackRange.Max
+			if state.valueKnown {
+				state.value = memoryCellFromIntegerConstraint(stackRange.Min)
+			}
+		}
+	case iotaFromZeroLexeme,
+		iotaFromOneLexeme:
+		{
+			count := newUnboundedStackRange(0)
+
+			if state.valueKnown {
+				n := state.value
+				if n > interpretCodeOptionsSaveStackMaxLen {
+					n = interpretCodeOptionsSaveStackMaxLen + 1
+				}
+
+				if node.lexeme == iotaFromOneLexeme && n > 0 {
+					n--
+				}
+
+				count = newStackRange(int(n), int(n))
+			}
+
+			state = analysis.push(node, state, count)
+		}
+	case pushStringLexeme:
+		{
+			count := newUnboundedStackRange(0)
+
+			if content, err := decodeStringLiteral(node.data); err == nil {
+				n := len([]rune(string(content)))
+				count = newStackRange(n, n)
+			}
+
+			state = analysis.push(node, state, count)
+		}
+	case giveStackTopLexeme:
+		state.updateOtherStacks(func(stackRange StackRange) StackRange {
+			return stackRange.add(newStackRange(1, 1)).clamp(interpretCodeOptionsSaveStackMaxLen)
+		})
+	case giveStackManyLexeme:
+		{
+			count := newUnboundedStackRange(0)
+
+			if state.valueKnown && state.value <= interpretCodeOptionsSaveStackMaxLen {
+				n := int(state.value)
+				count = newStackRange(n, n)
+
+				state = analysis.pop(node, state, n, n)
+			} else {
+				state.updateCurrentStacks(func(stackRange StackRange) StackRange {
+					stackRange.Min = 0
+					return stackRange
+				})
+			}
+
+			state.updateOtherStacks(func(stackRange StackRange) StackRange {
+				return stackRange.add(count).clamp(interpretCodeOptionsSaveStackMaxLen)
+			})
+		}
+	case giveStackWholeLexeme:
+		{
+			indices := before.currentStackIndices()
+
+			count := before.stacks[indices[0]]
+			for _, i := range indices[1:] {
+				count = count.join(before.stacks[i])
+			}
+
+			state.updateOtherStacks(func(stackRange StackRange) StackRange {
+				return stackRange.add(count).clamp(interpretCodeOptionsSaveStackMaxLen)
+			})
+		}
+	case readStackFromFileLexeme:
+		state.updateCurrentStacks(func(StackRange) StackRange {
+			return newUnboundedStackRange(0)
+		})
+	case resetStateLexeme:
+		state.value = 0
+		state.valueKnown = true
+		state.stacks = [stackAnalysisStackCount]StackRange{}
+	case useStackIndexZeroLexeme:
+		state.stackIndices = [stackAnalysisStackCount]bool{true, false}
+	case useStackIndexOneLexeme:
+		state.stackIndices = [stackAnalysisStackCount]bool{false, true}
+	case useStackIndexSwappedLexeme:
+		state.stackIndices[0], state.stackIndices[1] = state.stackIndices[1], state.stackIndices[0]
+	case callProcedureLexeme:
+		state = analysis.callProcedure(node, state)
+	case breakLoopLexeme,
+		continueLoopLexeme:
+		if len(analysis.loops) > 0 {
+			analysis.loops[len(analysis.loops)-1].add(node.lexeme, state)
+		}
+	case filePathLexeme:
+		state = analysis.includeFile(node, state)
+	case changeDirLexeme:
+		analysis.dir = string(node.data)
+	}
+
+	analysis.record(node, before, state)
+
+	return state
+}
+
+func (analysis *stackAnalysis) applyStackEffect(node *terminalTreeNode, state stackState) stackState {
+	effect := node.lexeme.stackEffect()
+
+	if effect.required > 0 || effect.popped > 0 {
+		state = analysis.pop(node, state, effect.required, effect.popped)
+	}
+
+	if effect.cleared {
+		state.updateCurrentStacks(func(StackRange) StackRange {
+			return newStackRange(0, 0)
+		})
+	}
+
+	if effect.pushed > 0 {
+		state = analysis.push(node, state, newStackRange(effect.pushed, effect.pushed))
+	}
+
+	return state
+}
+
+func (analysis *stackAnalysis) pop(node *terminalTreeNode, state stackState, required int, count int) stackState {
+	underflow := true
+	available := 0
+
+	for _, i := range state.currentStackIndices() {
+		stackRange := state.stacks[i]
+
+		if stackRange.MaxUnbounded || stackRange.Max >= required {
+			underflow = false
+			break
+		}
+
+		if stackRange.Max > available {
+			available = stackRange.Max
+		}
+	}
+
+	if underflow {
+		analysis.addDiagnostic(
+			node.span,
+			"`%s` always underflows the current stack: it needs %d value(s), but the stack holds at most %d at this point",
+			node.lexeme.sourceText(),
+			required,
+			available,
+		)
+	}
+
+	state.updateCurrentStacks(func(stackRange StackRange) StackRange {
+		return stackRange.subtract(count)
+	})
+
+	return state
+}
+
+func (analysis *stackAnalysis) push(node *terminalTreeNode, state stackState, count StackRange) stackState {
+	const limit = interpretCodeOptionsSaveStackMaxLen
+
+	indices := state.currentStackIndices()
+	overflow := true
+	mayOverflow := false
+	reachable := 0
+
+	for _, i := range indices {
+		stackRange := state.stacks[i].add(count)
+
+		if stackRange.Min <= limit {
+			overflow = false
+		}
+
+		if !stackRange.MaxUnbounded && stackRange.Max > limit {
+			mayOverflow = true
+			if stackRange.Max > reachable {
+				reachable = stackRange.Max
+			}
+		}
+	}
+
+	switch {
+	case overflow:
+		analysis.addDiagnostic(
+			node.span,
+			"`%s` always overflows the current stack: it needs room for at least %d value(s), but the limit is %d",
+			node.lexeme.sourceText(),
+			state.stacks[indices[0]].add(count).Min,
+			limit,
+		)
+	case mayOverflow:
+		analysis.addDiagnostic(
+			node.span,
+			"`%s` may overflow the current stack: it can need room for up to %d value(s), but the limit is %d",
+			node.lexeme.sourceText(),
+			reachable,
+			limit,
+		)
+	}
+
+	state.updateCurrentStacks(func(stackRange StackRange) StackRange {
+		return stackRange.add(count).clamp(limit)
+	})
+
+	return state
+}
+
+func (analysis *stackAnalysis) callProcedure(node *terminalTreeNode, state stackState) stackState {
+	name := string(node.data)
+
+	var procedureNode *parentTreeNode
+	if node.tree != nil {
+		procedureNode = node.tree.procedures[name]
+	}
+
+	if procedureNode == nil || analysis.calling[name] {
+		output := state
+		output.valueKnown = false
+
+		for i := range output.stacks {
+			output.stackIndices[i] = true
+			output.stacks[i] = newUnboundedStackRange(0)
+		}
+
+		return output
+	}
+
+	analysis.calling[name] = true
+
+	output := analysis.analyseNodes(procedureNode.childNodes, state)
+
+	delete(analysis.calling, name)
+
+	return output
+}
+
+func (analysis *stackAnalysis) includeFile(node *terminalTreeNode, state stackState) stackState {
+	if len(node.data) == 0 {
+		return state
+	}
+
+	filePath := string(node.data)
+	if !filepath.IsAbs(filePath) {
+		filePath = filepath.Join(analysis.dir, filePath)
+	}
+
+	content, err := os.ReadFile(filePath)
+
+	if filepath.Ext(filePath) != FileExtensionForCode {
+		count := newUnboundedStackRange(0)
+
+		if err == nil {
+			n := len([]rune(string(content)))
+			count = newStackRange(n, n)
+		}
+
+		return analysis.push(node, state, count)
+	}
+
+	output := state
+	output.valueKnown = false
+
+	if err != nil || analysis.visiting[filePath] {
+		return output
+	}
+
+	childOptions := analysis.options.Clone()
+	childOptions.WorkingDir = filepath.Dir(filePath)
+	childOptions.FilePath = filePath
+	childOptions.Overflow = node.overflow
+
+	var tr *tree
+
+	err = withWorkingDir(childOptions.WorkingDir, func() (err error) {
+		tokens, err := produceCleanTokens(content, childOptions)
+		if err != nil {
+			return
+		}
+
+		tr, err = produceTree(tokens, childOptions)
+
+		return
+	})
+	if err != nil {
+		return output
+	}
+
+	dir := analysis.dir
+	analysis.dir = childOptions.WorkingDir
+	analysis.visiting[filePath] = true
+
+	childState := analysis.analyseNodes(tr.rootNode.childNodes, state)
+
+	delete(analysis.visiting, filePath)
+	analysis.dir = dir
+
+	output.value = childState.value
+	output.valueKnown = childState.valueKnown
+
+	return output
+}
@@ -0,0 +1,35 @@
+package dorklang
+
+type SyntaxFormat int
+
+const (
+	SyntaxFormatMarkdown SyntaxFormat = iota
+	SyntaxFormatTextMate
+	SyntaxFormatVim
+)
+
+const (
+	syntaxTableStartMarker = "<!-- syntax-table:start -->"
+	syntaxTableEndMarker   = "<!-- syntax-table:end -->"
+	syntaxScopeSuffix      = ".dork"
+	syntaxVimGroupPrefix   = "dork"
+)
+
+type textMateGrammar struct {
+	Schema     string                     `json:"$schema"`
+	Name       string                     `json:"name"`
+	ScopeName  string                     `json:"scopeName"`
+	FileTypes  []string                   `json:"fileTypes"`
+	Patterns   []textMatePattern          `json:"patterns"`
+	Repository map[string]textMatePattern `json:"repository"`
+}
+
+type textMatePattern struct {
+	Name        string            `json:"name,omitempty"`
+	ContentName string            `json:"contentName,omitempty"`
+	Match       string            `json:"match,omitempty"`
+	Begin       string            `json:"begin,omitempty"`
+	End         string            `json:"end,omitempty"`
+	Include     string            `json:"include,omitempty"`
+	Patterns    []textMatePattern `json:"patterns,omitempty"`
+}
@@ -0,0 +1,300 @@
+package dorklang
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+func ParseSyntaxFormat(s string) (format SyntaxFormat, err error) {
+	switch strings.ToLower(s) {
+	case "markdown", "md":
+		format = SyntaxFormatMarkdown
+	case "textmate", "tmlanguage":
+		format = SyntaxFormatTextMate
+	case "vim":
+		format = SyntaxFormatVim
+	default:
+		err = ErrSyntaxFormatUnrecognized
+	}
+
+	return
+}
+
+func WriteSyntax(format SyntaxFormat, output io.Writer) (err error) {
+	switch format {
+	case SyntaxFormatMarkdown:
+		err = writeSyntaxTable(output)
+	case SyntaxFormatTextMate:
+		err = writeTextMateGrammar(output)
+	case SyntaxFormatVim:
+		err = writeVimSyntax(output)
+	default:
+		err = ErrSyntaxFormatUnrecognized
+	}
+
+	return
+}
+
+func ReplaceSyntaxTable(readme []byte) (output []byte, err error) {
+	start := bytes.Index(readme, []byte(syntaxTableStartMarker))
+	end := bytes.Index(readme, []byte(syntaxTableEndMarker))
+	if start == -1 || end == -1 || end < start {
+		err = ErrSyntaxTableMarkersUnfound
+		return
+	}
+
+	start += len(syntaxTableStartMarker)
+
+	var buffer bytes.Buffer
+
+	buffer.Write(readme[:start])
+	buffer.WriteByte('\n')
+
+	if err = writeSyntaxTable(&buffer); err != nil {
+		return
+	}
+
+	buffer.Write(readme[end:])
+
+	output = buffer.Bytes()
+
+	return
+}
+
+func writeSyntaxTable(output io.Writer) (err error) {
+	var builder strings.Builder
+
+	builder.WriteString("| Command | Function |\n| :--------: | ------- |\n")
+
+	for _, definition := range lexemeDefinitions {
+		if definition.description == "" || definition.kind == sectionEndLexemeKind {
+			continue
+		}
+
+		builder.WriteString("| ")
+
+		if len(definition.examples) > 0 {
+			for i, example := range definition.examples {
+				if i > 0 {
+					builder.WriteString(", ")
+				}
+
+				builder.WriteString(markdownCode(example))
+			}
+		} else {
+			builder.WriteString(markdownCode(definition.text))
+		}
+
+		if definition.kind == sectionStartLexemeKind {
+			builder.WriteString(" ... ")
+			builder.WriteString(markdownCode(definition.sectionEnd.sourceText()))
+		}
+
+		builder.WriteString(" | ")
+		builder.WriteString(definition.description)
+		builder.WriteString(" |\n")
+	}
+
+	_, err = io.WriteString(output, builder.String())
+
+	return
+}
+
+func markdownCode(text string) string {
+	text = strings.ReplaceAll(text, "|", `\|`)
+
+	var longestRun, run int
+	for _, r := range text {
+		if r == '`' {
+			run++
+			if run > longestRun {
+				longestRun = run
+			}
+		} else {
+			run = 0
+		}
+	}
+
+	if longestRun == 0 {
+		return "`" + text + "`"
+	}
+
+	fence := strings.Repeat("`", longestRun+1)
+
+	return fence + " " + text + " " + fence
+}
+
+func highlightedLexemeDefinitions() (definitions []lexemeDefinition) {
+	for _, definition := range lexemeDefinitions {
+		if !definition.isProducedByCommandText() || definition.category == commentLexemeCategory {
+			continue
+		}
+
+		definitions = append(definitions, definition)
+	}
+
+	sort.SliceStable(definitions, func(i, j int) bool {
+		return len(definitions[i].text) > len(definitions[j].text)
+	})
+
+	return
+}
+
+func patternedLexemeDefinitions() (definitions []lexemeDefinition) {
+	for _, definition := range lexemeDefinitions {
+		if definition.pattern != "" {
+			definitions = append(definitions, definition)
+		}
+	}
+
+	return
+}
+
+func writeTextMateGrammar(output io.Writer) (err error) {
+	commentPattern := textMatePattern{
+		Name:  commentLexemeCategory.textMateScope() + syntaxScopeSuffix,
+		Begin: regexp.QuoteMeta(startCommentSectionLexeme.sourceText()),
+		End:   regexp.QuoteMeta(endCommentSectionLexeme.sourceText()),
+		Patterns: []textMatePattern{
+			{Include: "#comment"},
+		},
+	}
+
+	includePattern := textMatePattern{
+		Name:        includeLexemeCategory.textMateScope() + syntaxScopeSuffix,
+		ContentName: "string.unquoted.path" + syntaxScopeSuffix,
+		Begin:       regexp.QuoteMeta(startReadFileSectionLexeme.sourceText()),
+		End:         regexp.QuoteMeta(endReadFileSectionLexeme.sourceText()),
+	}
+
+	commandPatterns := make([]textMatePattern, 0, len(lexemeDefinitions))
+	for _, definition := range patternedLexemeDefinitions() {
+		commandPatterns = append(commandPatterns, textMatePattern{
+			Name:  definition.category.textMateScope() + syntaxScopeSuffix,
+			Match: definition.pattern,
+		})
+	}
+	for _, definition := range highlightedLexemeDefinitions() {
+		commandPatterns = append(commandPatterns, textMatePattern{
+			Name:  definition.category.textMateScope() + syntaxScopeSuffix,
+			Match: regexp.QuoteMeta(definition.text),
+		})
+	}
+
+	grammar := textMateGrammar{
+		Schema:    "https://raw.githubusercontent.com/martinring/tmlanguage/master/tmlanguage.json",
+		Name:      "dorklang",
+		ScopeName: "source" + syntaxScopeSuffix,
+		FileTypes: []string{strings.TrimPrefix(FileExtensionForCode, ".")},
+		Patterns: []textMatePattern{
+			{Include: "#include"},
+			{Include: "#comment"},
+			{Include: "#commands"},
+		},
+		Repository: map[string]textMatePattern{
+			"include":  includePattern,
+			"comment":  commentPattern,
+			"commands": {Patterns: commandPatterns},
+		},
+	}
+
+	encoder := json.NewEncoder(output)
+	encoder.SetIndent("", "\t")
+	encoder.SetEscapeHTML(false)
+
+	err = encoder.Encode(grammar)
+
+	return
+}
+
+func writeVimSyntax(output io.Writer) (err error) {
+	var builder strings.Builder
+
+	builder.WriteString("\" Vim syntax file\n\" Language: dorklang\n\" Generated by the syntax command of the dorklang interpreter.\n\n")
+	builder.WriteString("if exists(\"b:current_syntax\")\n\tfinish\nendif\n\n")
+
+	definitions := highlightedLexemeDefinitions()
+	categories := make([]lexemeCategory, 0, len(definitions))
+	categoriesSeen := make(map[lexemeCategory]bool)
+
+	// later items take priority in vim, so the longest texts are defined last
+	for i := len(definitions) - 1; i >= 0; i-- {
+		definition := definitions[i]
+		group, _ := definition.category.vimGroup()
+
+		fmt.Fprintf(&builder, "syntax match %s /%s/\n", group, vimVeryNoMagic(definition.text))
+
+		if !categoriesSeen[definition.category] {
+			categoriesSeen[definition.category] = true
+			categories = append(categories, definition.category)
+		}
+	}
+
+	for _, definition := range patternedLexemeDefinitions() {
+		group, _ := definition.category.vimGroup()
+
+		fmt.Fprintf(&builder, "syntax match %s /\\v%s/\n", group, strings.ReplaceAll(definition.vimPattern, "/", `\/`))
+	}
+
+	commentGroup, _ := commentLexemeCategory.vimGroup()
+	includeGroup, _ := includeLexemeCategory.vimGroup()
+
+	fmt.Fprintf(
+		&builder,
+		"syntax region %s start=/%s/ end=/%s/ contains=%s\n",
+		commentGroup,
+		vimVeryNoMagic(startCommentSectionLexeme.sourceText()),
+		vimVeryNoMagic(endCommentSectionLexeme.sourceText()),
+		commentGroup,
+	)
+	fmt.Fprintf(
+		&builder,
+		"syntax region %s start=/%s/ end=/%s/\n\n",
+		includeGroup,
+		vimVeryNoMagic(startReadFileSectionLexeme.sourceText()),
+		vimVeryNoMagic(endReadFileSectionLexeme.sourceText()),
+	)
+
+	sort.Slice(categories, func(i, j int) bool {
+		return categories[i] < categories[j]
+	})
+	categories = append(categories, commentLexemeCategory, includeLexemeCategory)
+
+	for _, category := range categories {
+		group, link := category.vimGroup()
+
+		fmt.Fprintf(&builder, "highlight default link %s %s\n", group, link)
+	}
+
+	builder.WriteString("\nlet b:current_syntax = \"dork\"\n")
+
+	_, err = io.WriteString(output, builder.String())
+
+	return
+}
+
+func vimVeryNoMagic(text string) string {
+	var builder strings.Builder
+
+	builder.WriteString(`\V`)
+
+	for _, r := range text {
+		switch r {
+		case '\\', '/':
+			builder.WriteByte('\\')
+			builder.WriteRune(r)
+		case '^', '$':
+			// these still anchor at the ends of a pattern, even in very nomagic mode
+			fmt.Fprintf(&builder, `\%%d%d`, r)
+		default:
+			builder.WriteRune(r)
+		}
+	}
+
+	return builder.String()
+}
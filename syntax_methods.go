@@ -0,0 +1,86 @@
+package dorklang
+
+func (format SyntaxFormat) String() string {
+	switch format {
+	case SyntaxFormatMarkdown:
+		return "markdown"
+	case SyntaxFormatTextMate:
+		return "textmate"
+	case SyntaxFormatVim:
+		return "vim"
+	}
+
+	return "unknown"
+}
+
+func (category lexemeCategory) textMateScope() string {
+	switch category {
+	case arithmeticLexemeCategory:
+		return "keyword.operator.arithmetic"
+	case logicLexemeCategory:
+		return "keyword.operator.logical"
+	case bitwiseLexemeCategory:
+		return "keyword.operator.bitwise"
+	case valueLexemeCategory:
+		return "constant.numeric"
+	case inputOutputLexemeCategory:
+		return "support.function.io"
+	case stackLexemeCategory:
+		return "storage.modifier.stack"
+	case fileLexemeCategory:
+		return "support.function.file"
+	case contextLexemeCategory:
+		return "punctuation.section.context"
+	case loopLexemeCategory:
+		return "keyword.control.loop"
+	case conditionLexemeCategory:
+		return "keyword.control.conditional"
+	case procedureLexemeCategory:
+		return "entity.name.function"
+	case commentLexemeCategory:
+		return "comment.block"
+	case includeLexemeCategory:
+		return "meta.include"
+	case modifierLexemeCategory:
+		return "keyword.operator.modifier"
+	}
+
+	return ""
+}
+
+func (category lexemeCategory) vimGroup() (group string, link string) {
+	switch category {
+	case arithmeticLexemeCategory:
+		group, link = "Arithmetic", "Operator"
+	case logicLexemeCategory:
+		group, link = "Logic", "Conditional"
+	case bitwiseLexemeCategory:
+		group, link = "Bitwise", "Operator"
+	case valueLexemeCategory:
+		group, link = "Value", "Number"
+	case inputOutputLexemeCategory:
+		group, link = "InputOutput", "Function"
+	case stackLexemeCategory:
+		group, link = "Stack", "Type"
+	case fileLexemeCategory:
+		group, link = "File", "Function"
+	case contextLexemeCategory:
+		group, link = "Context", "Delimiter"
+	case loopLexemeCategory:
+		group, link = "Loop", "Repeat"
+	case conditionLexemeCategory:
+		group, link = "Condition", "Conditional"
+	case procedureLexemeCategory:
+		group, link = "Procedure", "Function"
+	case commentLexemeCategory:
+		group, link = "Comment", "Comment"
+	case includeLexemeCategory:
+		group, link = "Include", "Include"
+	case modifierLexemeCategory:
+		group, link = "Modifier", "Special"
+	}
+
+	group = syntaxVimGroupPrefix + group
+
+	return
+}
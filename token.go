@@ -2,6 +2,10 @@ package dorklang
 
 const (
 	tokenDataSeparatorByte = byte(0)
+	stringLiteralStart     = "%{"
+	stringLiteralEnd       = "}"
+	stringLiteralEscape    = '\\'
+	procedureNameMaxLen    = 32
 )
 
 var (
@@ -10,8 +14,9 @@ var (
 
 type token struct {
 	lex             lexeme
-	data            []byte          // used only when lex == filePathLexeme
+	data            []byte          // used only when lex is filePathLexeme or a literal
 	childCollection tokenCollection // used only when lex == parentLexeme
+	span            sourceSpan
 }
 
 type tokenCollection []token
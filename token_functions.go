@@ -2,560 +2,183 @@ package dorklang
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
-	"unicode"
+	"strconv"
+	"unicode/utf8"
 )
 
-func produceTokens(input []byte) (output tokenCollection, err error) {
-	output = make(tokenCollection, 0, len(input)+2)
-	sectionStack := make([]lexeme, 0, len(input)/2+1)
+func produceTokens(input []byte, filePath string) (output tokenCollection, err error) {
+	output, err = produceTokensFromReader(bytes.NewReader(input), filePath)
 
-	output = append(output, token{lex: startProgramLexeme})
+	return
+}
+
+// the tokens are still collected, since cleaning and building the tree look both ways through sections,
+// but any lexemes that the caller has no use for are dropped as soon as they are lexed
+func produceTokensFromReader(reader io.Reader, filePath string, ignoreLexemes ...lexeme) (output tokenCollection, err error) {
+	lexer := newTokenLexer(reader, filePath)
 
-	for i, r := range input {
-		l := invalidLexeme
-		var d []byte
+	for {
+		var t token
 
-		sectionStackTopLexeme := invalidLexeme
-		if len(sectionStack) > 0 {
-			sectionStackTopLexeme = sectionStack[len(sectionStack)-1]
+		t, err = lexer.next()
+		if err == io.EOF {
+			err = nil
+			break
+		}
+		if err != nil {
+			return
 		}
 
-		if sectionStackTopLexeme == startCommentSectionLexeme {
-			switch r {
-			case '{':
-				if len(output) > 0 && output[len(output)-1].lex == startCommentSectionLexeme {
-					if len(sectionStack) == 0 {
-						err = ErrLexemeSectionStackEmpty
-						return
-					}
-					if sectionStack[len(sectionStack)-1] != startCommentSectionLexeme {
-						err = ErrLexemeSectionStackNoMatch
-						return
-					}
-					sectionStack[len(sectionStack)-1] = startReadFileSectionLexeme
-					output[len(output)-1].lex = startReadFileSectionLexeme
-				} else {
-					l = startCommentSectionLexeme
-					sectionStack = append(sectionStack, l)
-				}
-			case '}':
-				l = endCommentSectionLexeme
-				sectionStack = sectionStack[:len(sectionStack)-1]
+		ignored := false
+
+		for _, l := range ignoreLexemes {
+			if t.lex == l {
+				ignored = true
+				break
 			}
-		} else if sectionStackTopLexeme == startReadFileSectionLexeme {
-			switch r {
-			case '}':
-				{
-					if i+1 >= len(input) || input[i+1] != r {
-						err = ErrLexemeSectionStackNoMatch
-						return
-					}
+		}
 
-					input[i+1] = ' '
+		if !ignored {
+			output = append(output, t)
+		}
+	}
 
-					if len(sectionStack) == 0 {
-						err = ErrNoMatchSectionCharacters
-						return
-					}
+	return
+}
 
-					l = endReadFileSectionLexeme
-					sectionStack = sectionStack[:len(sectionStack)-1]
-				}
-			default:
-				{
-					var handled bool
+func numericLiteralAbsorbsRune(data []byte, r rune) bool {
+	switch {
+	case string(data) == "0" && (r == 'x' || r == 'X'):
+		return true
+	case len(data) > 1 && (data[1] == 'x' || data[1] == 'X'):
+		return r >= '0' && r <= '9' || r >= 'a' && r <= 'f' || r >= 'A' && r <= 'F'
+	}
 
-					if unicode.IsSpace(rune(r)) {
-						if len(output) == 0 || output[len(output)-1].lex != separatorLexeme {
-							l = separatorLexeme
-						}
+	return r >= '0' && r <= '9'
+}
 
-						handled = true
-					}
+func parseNumericLiteral(data []byte) (value memoryCell, err error) {
+	text := string(data)
+	base := 10
 
-					if !handled {
-						if len(output) == 0 || output[len(output)-1].lex != filePathLexeme {
-							l = filePathLexeme
-							d = append(d, r)
-						}
+	if len(text) > 1 && (text[1] == 'x' || text[1] == 'X') {
+		text = text[2:]
+		base = 16
+	}
 
-						if len(output) != 0 && output[len(output)-1].lex == filePathLexeme {
-							output[len(output)-1].data = append(output[len(output)-1].data, r)
-						}
-					}
-				}
-			}
+	n, err := strconv.ParseUint(text, base, 64)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			err = fmt.Errorf("%w: %s", ErrNumericLiteralOutOfRange, data)
 		} else {
-			switch r {
-			case '+':
-				{
-					outputLen := len(output)
-					lastOutput := invalidLexeme
-
-					if outputLen > 0 {
-						lastOutput = output[outputLen-1].lex
-					}
-
-					switch lastOutput {
-					case addStackPairLexeme:
-						output[outputLen-1].lex = addStackWholeLexeme
-					case modifierLexeme:
-						output[outputLen-1].lex = addStackPairLexeme
-					case addOneLexeme:
-						output[outputLen-1].lex = addEightLexeme
-					default:
-						l = addOneLexeme
-					}
-				}
-			case '-':
-				{
-					outputLen := len(output)
-					lastOutput := invalidLexeme
-
-					if outputLen > 0 {
-						lastOutput = output[outputLen-1].lex
-					}
-
-					switch lastOutput {
-					case subtractStackPairLexeme:
-						output[outputLen-1].lex = subtractStackWholeLexeme
-					case modifierLexeme:
-						output[outputLen-1].lex = subtractStackPairLexeme
-					case subtractOneLexeme:
-						output[outputLen-1].lex = subtractEightLexeme
-					default:
-						l = subtractOneLexeme
-					}
-				}
-			case '*':
-				{
-					outputLen := len(output)
-					lastOutput := invalidLexeme
-
-					if outputLen > 0 {
-						lastOutput = output[outputLen-1].lex
-					}
-
-					switch lastOutput {
-					case multiplyStackPairLexeme:
-						output[outputLen-1].lex = multiplyStackWholeLexeme
-					case modifierLexeme:
-						output[outputLen-1].lex = multiplyStackPairLexeme
-					case multiplyTwoLexeme:
-						output[outputLen-1].lex = multiplyEightLexeme
-					default:
-						l = multiplyTwoLexeme
-					}
-				}
-			case '/':
-				{
-					outputLen := len(output)
-					lastOutput := invalidLexeme
-
-					if outputLen > 0 {
-						lastOutput = output[outputLen-1].lex
-					}
+			err = fmt.Errorf("%w: %s", ErrNumericLiteralInvalid, data)
+		}
 
-					switch lastOutput {
-					case divideStackPairLexeme:
-						output[outputLen-1].lex = divideStackWholeLexeme
-					case modifierLexeme:
-						output[outputLen-1].lex = divideStackPairLexeme
-					case divideTwoLexeme:
-						output[outputLen-1].lex = divideEightLexeme
-					default:
-						l = divideTwoLexeme
-					}
-				}
-			case '^':
-				if len(output) > 0 && output[len(output)-1].lex == squareLexeme {
-					output[len(output)-1].lex = cubeLexeme
-				} else {
-					l = squareLexeme
-				}
-			case '!':
-				if len(output) > 0 && output[len(output)-1].lex == printCharacterLexeme {
-					output[len(output)-1].lex = printNumberLexeme
-				} else {
-					l = printCharacterLexeme
-				}
-			case '?':
-				if len(output) > 0 && output[len(output)-1].lex == inputCharacterLexeme {
-					output[len(output)-1].lex = inputNumberLexeme
-				} else {
-					l = inputCharacterLexeme
-				}
-			case '~':
-				l = setZeroLexeme
-			case '\'':
-				{
-					outputLen := len(output)
-					lastOutput := invalidLexeme
-
-					if outputLen > 0 {
-						lastOutput = output[outputLen-1].lex
-					}
+		return
+	}
 
-					switch lastOutput {
-					case setOneMebibyteLexeme:
-						output[outputLen-1].lex = setEightMebibyteLexeme
-					case modifierLexeme:
-						output[outputLen-1].lex = setOneMebibyteLexeme
-					case setOneByteLexeme:
-						output[outputLen-1].lex = setEightByteLexeme
-					default:
-						l = setOneByteLexeme
-					}
-				}
-			case '"':
-				{
-					outputLen := len(output)
-					lastOutput := invalidLexeme
+	value = memoryCell(n)
 
-					if outputLen > 0 {
-						lastOutput = output[outputLen-1].lex
-					}
+	return
+}
 
-					switch lastOutput {
-					case setOneGibibyteLexeme:
-						output[outputLen-1].lex = setEightGibibyteLexeme
-					case modifierLexeme:
-						output[outputLen-1].lex = setOneGibibyteLexeme
-					case setOneKibibyteLexeme:
-						output[outputLen-1].lex = setEightKibibyteLexeme
-					default:
-						l = setOneKibibyteLexeme
-					}
-				}
-			case '`':
-				if len(output) > 0 && output[len(output)-1].lex == setRandomByteLexeme {
-					output[len(output)-1].lex = setRandomMaxLexeme
-				} else {
-					l = setRandomByteLexeme
-				}
-			case '@':
-				if len(output) > 0 && output[len(output)-1].lex == setSecondTimestampLexeme {
-					output[len(output)-1].lex = setNanosecondTimestampLexeme
-				} else {
-					l = setSecondTimestampLexeme
-				}
-			case '$':
-				{
-					outputLen := len(output)
-					lastOutput := invalidLexeme
+func nameAbsorbsRune(r rune) bool {
+	return r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r == '_'
+}
 
-					if outputLen > 0 {
-						lastOutput = output[outputLen-1].lex
-					}
+func checkProcedureName(data []byte) (err error) {
+	if len(data) == 0 || len(data) > procedureNameMaxLen {
+		err = fmt.Errorf("%w: %q", ErrProcedureNameInvalid, data)
+	}
 
-					switch lastOutput {
-					case modifierLexeme:
-						output[outputLen-1].lex = useStackIndexSwappedLexeme
-					case useStackIndexZeroLexeme:
-						output[outputLen-1].lex = useStackIndexOneLexeme
-					default:
-						l = useStackIndexZeroLexeme
-					}
-				}
-			case ':':
-				if len(output) > 0 && output[len(output)-1].lex == modifierLexeme {
-					output[len(output)-1].lex = countStackLexeme
-				} else {
-					l = pushStackLexeme
-				}
-			case ';':
-				if len(output) > 0 && output[len(output)-1].lex == modifierLexeme {
-					output[len(output)-1].lex = popStackRandomLexeme
-				} else {
-					l = popStackLastLexeme
-				}
-			case '#':
-				if len(output) > 0 && output[len(output)-1].lex == hashStackOneByteLexeme {
-					output[len(output)-1].lex = hashStackEightByteLexeme
-				} else {
-					l = hashStackOneByteLexeme
-				}
-			case '&':
-				{
-					outputLen := len(output)
-					lastOutput := invalidLexeme
+	return
+}
 
-					if outputLen > 0 {
-						lastOutput = output[outputLen-1].lex
-					}
+func decodeStringLiteral(data []byte) (decoded []byte, err error) {
+	decoded = make([]byte, 0, len(data))
 
-					switch lastOutput {
-					case logicalAndStackPairLexeme:
-						output[outputLen-1].lex = logicalAndStackWholeLexeme
-					case modifierLexeme:
-						output[outputLen-1].lex = logicalAndStackPairLexeme
-					}
-				}
-			case '\\':
-				l = invertLexeme
-			case '.':
-				l = writeStackToFileLexeme
-			case ',':
-				l = readStackFromFileLexeme
-			case 's':
-				{
-					outputLen := len(output)
-					lastOutput := invalidLexeme
-
-					if outputLen > 0 {
-						lastOutput = output[outputLen-1].lex
-					}
+	for i := 0; i < len(data); i++ {
+		if data[i] != stringLiteralEscape {
+			decoded = append(decoded, data[i])
+			continue
+		}
 
-					switch lastOutput {
-					case modifierLexeme:
-						output[outputLen-1].lex = shuffleStackLexeme
-					case sortStackAscendingLexeme:
-						output[outputLen-1].lex = sortStackDescendingLexeme
-					default:
-						l = sortStackAscendingLexeme
-					}
-				}
-			case 'x':
-				l = swapStackTopLexeme
-			case 'r':
-				l = reverseStackLexeme
-			case 'i':
-				if len(output) > 0 && output[len(output)-1].lex == iotaFromZeroLexeme {
-					output[len(output)-1].lex = iotaFromOneLexeme
-				} else {
-					l = iotaFromZeroLexeme
-				}
-			case '|':
-				{
-					outputLen := len(output)
-					lastOutput := invalidLexeme
+		start := i
+		i++
 
-					if outputLen > 0 {
-						lastOutput = output[outputLen-1].lex
-					}
+		if i >= len(data) {
+			err = fmt.Errorf("%w: %s", ErrStringLiteralEscapeInvalid, data[start:])
+			return
+		}
 
-					switch lastOutput {
-					case modifierLexeme:
-						output[outputLen-1].lex = resetStateLexeme
-					case deleteFileLexeme:
-						output[outputLen-1].lex = clearStackLexeme
-					default:
-						l = deleteFileLexeme
-					}
-				}
-			case '(':
-				if len(output) > 0 && output[len(output)-1].lex == startAdditionSectionLexeme {
-					if len(sectionStack) == 0 {
-						err = ErrLexemeSectionStackEmpty
-						return
-					}
-					if sectionStack[len(sectionStack)-1] != startAdditionSectionLexeme {
-						err = ErrLexemeSectionStackNoMatch
-						return
-					}
-					sectionStack[len(sectionStack)-1] = startMultiplicationSectionLexeme
-					output[len(output)-1].lex = startMultiplicationSectionLexeme
-				} else {
-					l = startAdditionSectionLexeme
-					sectionStack = append(sectionStack, l)
+		switch data[i] {
+		case stringLiteralEscape, stringLiteralEnd[0]:
+			decoded = append(decoded, data[i])
+		case 'n':
+			decoded = append(decoded, '\n')
+		case 'r':
+			decoded = append(decoded, '\r')
+		case 't':
+			decoded = append(decoded, '\t')
+		case '0':
+			decoded = append(decoded, 0)
+		case 'x':
+			{
+				if i+2 >= len(data) {
+					err = fmt.Errorf("%w: %s", ErrStringLiteralEscapeInvalid, data[start:])
+					return
 				}
-			case ')':
-				{
-					var localLexeme lexeme
-
-					if len(output) > 0 && output[len(output)-1].lex == endAdditionSectionLexeme {
-						localLexeme = endMultiplicationSectionLexeme
-						output[len(output)-1].lex = localLexeme
-					} else {
-						localLexeme = endAdditionSectionLexeme
-						l = localLexeme
-					}
-
-					if len(sectionStack) == 0 {
-						err = ErrNoMatchSectionCharacters
-						return
-					}
-
-					switch localLexeme {
-					case endAdditionSectionLexeme:
-						if i+1 < len(input) && input[i+1] == r {
-							localLexeme = invalidLexeme
-						} else {
-							localLexeme = startAdditionSectionLexeme
-						}
-					case endMultiplicationSectionLexeme:
-						localLexeme = startMultiplicationSectionLexeme
-					default:
-						err = ErrLexemeSectionStackNoMatch
-						return
-					}
 
-					if localLexeme != invalidLexeme {
-						if sectionStack[len(sectionStack)-1] != localLexeme {
-							err = ErrLexemeSectionStackNoMatch
-							return
-						}
-
-						sectionStack = sectionStack[:len(sectionStack)-1]
-					}
-				}
-			case '[':
-				if len(output) > 0 && output[len(output)-1].lex == startSubtractionSectionLexeme {
-					if len(sectionStack) == 0 {
-						err = ErrLexemeSectionStackEmpty
-						return
-					}
-					if sectionStack[len(sectionStack)-1] != startSubtractionSectionLexeme {
-						err = ErrLexemeSectionStackNoMatch
-						return
-					}
-					sectionStack[len(sectionStack)-1] = startDivisionSectionLexeme
-					output[len(output)-1].lex = startDivisionSectionLexeme
-				} else {
-					l = startSubtractionSectionLexeme
-					sectionStack = append(sectionStack, l)
+				n, parseErr := strconv.ParseUint(string(data[i+1:i+3]), 16, 8)
+				if parseErr != nil {
+					err = fmt.Errorf("%w: %s", ErrStringLiteralEscapeInvalid, data[start:i+3])
+					return
 				}
-			case ']':
-				{
-					var localLexeme lexeme
-
-					if len(output) > 0 && output[len(output)-1].lex == endSubtractionSectionLexeme {
-						localLexeme = endDivisionSectionLexeme
-						output[len(output)-1].lex = localLexeme
-					} else {
-						localLexeme = endSubtractionSectionLexeme
-						l = localLexeme
-					}
-
-					if len(sectionStack) == 0 {
-						err = ErrNoMatchSectionCharacters
-						return
-					}
-
-					switch localLexeme {
-					case endSubtractionSectionLexeme:
-						if i+1 < len(input) && input[i+1] == r {
-							localLexeme = invalidLexeme
-						} else {
-							localLexeme = startSubtractionSectionLexeme
-						}
-					case endDivisionSectionLexeme:
-						localLexeme = startDivisionSectionLexeme
-					default:
-						err = ErrLexemeSectionStackNoMatch
-						return
-					}
 
-					if localLexeme != invalidLexeme {
-						if sectionStack[len(sectionStack)-1] != localLexeme {
-							err = ErrLexemeSectionStackNoMatch
-							return
-						}
-
-						sectionStack = sectionStack[:len(sectionStack)-1]
-					}
-				}
-			case '<':
-				if len(output) > 0 && output[len(output)-1].lex == startJumpIfPositiveSectionLexeme {
-					if len(sectionStack) == 0 {
-						err = ErrLexemeSectionStackEmpty
-						return
-					}
-					if sectionStack[len(sectionStack)-1] != startJumpIfPositiveSectionLexeme {
-						err = ErrLexemeSectionStackNoMatch
-						return
-					}
-					sectionStack[len(sectionStack)-1] = startJumpIfZeroSectionLexeme
-					output[len(output)-1].lex = startJumpIfZeroSectionLexeme
-				} else {
-					l = startJumpIfPositiveSectionLexeme
-					sectionStack = append(sectionStack, l)
+				decoded = utf8.AppendRune(decoded, rune(n))
+				i += 2
+			}
+		case 'u':
+			{
+				end := bytes.IndexByte(data[i:], stringLiteralEnd[0])
+				if i+1 >= len(data) || data[i+1] != '{' || end == -1 {
+					err = fmt.Errorf("%w: %s", ErrStringLiteralEscapeInvalid, data[start:])
+					return
 				}
-			case '>':
-				{
-					var localLexeme lexeme
-
-					if len(output) > 0 && output[len(output)-1].lex == endJumpIfPositiveSectionLexeme {
-						localLexeme = endJumpIfZeroSectionLexeme
-						output[len(output)-1].lex = localLexeme
-					} else {
-						localLexeme = endJumpIfPositiveSectionLexeme
-						l = localLexeme
-					}
-
-					if len(sectionStack) == 0 {
-						err = ErrNoMatchSectionCharacters
-						return
-					}
-
-					switch localLexeme {
-					case endJumpIfPositiveSectionLexeme:
-						if i+1 < len(input) && input[i+1] == r {
-							localLexeme = invalidLexeme
-						} else {
-							localLexeme = startJumpIfPositiveSectionLexeme
-						}
-					case endJumpIfZeroSectionLexeme:
-						localLexeme = startJumpIfZeroSectionLexeme
-					default:
-						err = ErrLexemeSectionStackNoMatch
-						return
-					}
+				end += i
 
-					if localLexeme != invalidLexeme {
-						if sectionStack[len(sectionStack)-1] != localLexeme {
-							err = ErrLexemeSectionStackNoMatch
-							return
-						}
-
-						sectionStack = sectionStack[:len(sectionStack)-1]
-					}
+				n, parseErr := strconv.ParseUint(string(data[i+2:end]), 16, 32)
+				if parseErr != nil || !utf8.ValidRune(rune(n)) {
+					err = fmt.Errorf("%w: %s", ErrStringLiteralEscapeInvalid, data[start:end+1])
+					return
 				}
-			case '{':
-				l = startCommentSectionLexeme
-				sectionStack = append(sectionStack, l)
-			case '%':
-				l = modifierLexeme
-			default:
-				{
-					var handled bool
-
-					if unicode.IsSpace(rune(r)) {
-						if len(output) == 0 || output[len(output)-1].lex != separatorLexeme {
-							l = separatorLexeme
-						}
-
-						handled = true
-					}
 
-					if !handled {
-						err = ErrLexemeUnrecognized
-						return
-					}
-				}
+				decoded = utf8.AppendRune(decoded, rune(n))
+				i = end
 			}
-		}
-
-		if l != invalidLexeme {
-			output = append(output, token{
-				lex:  l,
-				data: d,
-			})
+		default:
+			err = fmt.Errorf("%w: %s", ErrStringLiteralEscapeInvalid, data[start:i+1])
+			return
 		}
 	}
 
-	if len(sectionStack) != 0 {
-		err = ErrNoMatchSectionCharacters
-	}
+	return
+}
+
+func cleanTokens(input tokenCollection, options InterpretCodeOptions) (err error) {
+	simplifyTokens(input)
 
-	output = append(output, token{lex: endProgramLexeme})
+	err = includeTokenFiles(input, options)
 
 	return
 }
 
-func cleanTokens(input tokenCollection) (err error) {
+func simplifyTokens(input tokenCollection) {
 	for i, t := range input {
 		switch t.lex {
 		case reverseStackLexeme:
@@ -721,69 +344,77 @@ func cleanTokens(input tokenCollection) (err error) {
 					}
 				}
 			}
-		case filePathLexeme:
-			{
-				if len(t.data) == 0 {
-					break
-				}
+		}
+	}
+}
 
-				filePath := string(t.data)
+func includeTokenFiles(input tokenCollection, options InterpretCodeOptions) (err error) {
+	for i, t := range input {
+		if t.lex != filePathLexeme || len(t.data) == 0 {
+			continue
+		}
 
-				var fileAbsPath string
-				fileAbsPath, err = filepath.Abs(filePath)
-				if err != nil {
-					return
-				}
+		filePath := string(t.data)
 
-				var initialDir string
-				initialDir, err = os.Getwd()
-				if err != nil {
-					return
-				}
+		var fileAbsPath string
+		fileAbsPath, err = filepath.Abs(filePath)
+		if err != nil {
+			return
+		}
 
-				fileDir := filepath.Dir(fileAbsPath)
+		var initialDir string
+		initialDir, err = os.Getwd()
+		if err != nil {
+			return
+		}
 
-				err = os.Chdir(fileDir)
-				if err != nil {
-					return
-				}
+		fileDir := filepath.Dir(fileAbsPath)
 
-				var content []byte
-				content, err = os.ReadFile(fileAbsPath)
-				if err != nil {
-					return
-				}
+		err = os.Chdir(fileDir)
+		if err != nil {
+			return
+		}
 
-				fileExt := filepath.Ext(filePath)
+		var content []byte
+		content, err = os.ReadFile(fileAbsPath)
+		if err != nil {
+			return
+		}
 
-				if fileExt == FileExtensionForCode {
-					var childTokenCollection tokenCollection
-					childTokenCollection, err = produceTokens(content)
-					if err != nil {
-						return
-					}
+		fileExt := filepath.Ext(filePath)
 
-					err = cleanTokens(childTokenCollection)
-					if err != nil {
-						return
-					}
+		if fileExt == FileExtensionForCode {
+			var childOptions InterpretCodeOptions
+			childOptions, err = options.withInclude(fileAbsPath)
+			if err != nil {
+				return
+			}
 
-					input[i].lex = parentLexeme
-					input[i].childCollection = childTokenCollection
-					input[i].data = bytes.Join(
-						[][]byte{
-							[]byte(fileDir),
-							[]byte(initialDir),
-						},
-						tokenDataSeparatorByteSlice,
-					)
-				}
+			var childTokenCollection tokenCollection
+			childTokenCollection, err = produceTokens(content, fileAbsPath)
+			if err != nil {
+				return
+			}
 
-				err = os.Chdir(initialDir)
-				if err != nil {
-					return
-				}
+			err = cleanTokens(childTokenCollection, childOptions)
+			if err != nil {
+				return
 			}
+
+			input[i].lex = parentLexeme
+			input[i].childCollection = childTokenCollection
+			input[i].data = bytes.Join(
+				[][]byte{
+					[]byte(fileDir),
+					[]byte(initialDir),
+				},
+				tokenDataSeparatorByteSlice,
+			)
+		}
+
+		err = os.Chdir(initialDir)
+		if err != nil {
+			return
 		}
 	}
 
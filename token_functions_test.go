@@ -0,0 +1,147 @@
+package dorklang
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+var produceTokensReaderInputs = map[string]string{
+	"commands":           `+ ++ %[+%] <<+>> %?+%!-%. 26 0x1a`,
+	"procedure":          "%(fib_2\n&fib_2%)&fib_2+",
+	"two-byte string":    "%{é\\}}:",
+	"four-byte string":   "%{\U0001F600\U0001F600}\n;",
+	"comment":            "{ ünïcödé \U0001F600 }+",
+	"include":            "{{ dïr/fïlé.txt }}",
+	"invalid utf-8":      "%{\xff\xc3}",
+	"newlines":           "+\r\n\n-\n",
+	"literal at the end": "++ 12345",
+}
+
+func TestProduceTokensFromReaderMatchesBytes(t *testing.T) {
+	readers := map[string]func([]byte) io.Reader{
+		"one byte": func(input []byte) io.Reader {
+			return iotest.OneByteReader(bytes.NewReader(input))
+		},
+		"data with error": func(input []byte) io.Reader {
+			return iotest.DataErrReader(bytes.NewReader(input))
+		},
+		"one byte with error": func(input []byte) io.Reader {
+			return iotest.DataErrReader(iotest.OneByteReader(bytes.NewReader(input)))
+		},
+		"half": func(input []byte) io.Reader {
+			return iotest.HalfReader(bytes.NewReader(input))
+		},
+	}
+
+	for name, input := range sourceInputs(t, produceTokensReaderInputs) {
+		expectedTokens, err := produceTokens(input, "")
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		expected := tokenStreamText(expectedTokens)
+
+		for readerName, newReader := range readers {
+			t.Run(name+"/"+readerName, func(t *testing.T) {
+				tokens, err := produceTokensFromReader(newReader(input), "")
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if actual := tokenStreamText(tokens); actual != expected {
+					t.Errorf("tokens differ from the []byte path:\n%s\n---\n%s", actual, expected)
+				}
+			})
+		}
+	}
+}
+
+func TestProduceTokensFromReaderErrors(t *testing.T) {
+	errRead := errors.New("read failed")
+
+	testCases := []struct {
+		name     string
+		input    string
+		position Position
+	}{
+		{
+			name:     "inside string",
+			input:    "%{ab",
+			position: Position{Offset: 4, Line: 1, Column: 5},
+		},
+		{
+			name:     "inside comment",
+			input:    "+\n{ ab",
+			position: Position{Offset: 6, Line: 2, Column: 5},
+		},
+		{
+			name:     "inside literal",
+			input:    "12",
+			position: Position{Offset: 2, Line: 1, Column: 3},
+		},
+		{
+			name:     "inside hexadecimal literal",
+			input:    "0x",
+			position: Position{Offset: 2, Line: 1, Column: 3},
+		},
+		{
+			name:     "inside string escape",
+			input:    "%{a\\",
+			position: Position{Offset: 4, Line: 1, Column: 5},
+		},
+		{
+			name:     "inside file path end",
+			input:    "{{a}",
+			position: Position{Offset: 3, Line: 1, Column: 4},
+		},
+		{
+			name:     "inside multi-byte rune",
+			input:    "%{é\xf0\x9f",
+			position: Position{Offset: 6, Line: 1, Column: 6},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			reader := io.MultiReader(iotest.OneByteReader(strings.NewReader(testCase.input)), iotest.ErrReader(errRead))
+
+			_, err := produceTokensFromReader(reader, "")
+			if !errors.Is(err, errRead) {
+				t.Fatalf("expected the error from the reader, got %v", err)
+			}
+
+			var positionErr *PositionError
+			if !errors.As(err, &positionErr) {
+				t.Fatalf("expected a position, got %v", err)
+			}
+
+			if positionErr.Position != testCase.position {
+				t.Errorf("expected position %+v, got %+v", testCase.position, positionErr.Position)
+			}
+		})
+	}
+}
+
+func TestProduceTokensFromReaderIgnoresLexemes(t *testing.T) {
+	// a long run of whitespace would once have reserved a token for every byte
+	input := strings.Repeat(" \n", 1<<16) + "+ +" + strings.Repeat("\t", 1<<16)
+
+	tokens, err := produceTokensFromReader(strings.NewReader(input), "", separatorLexeme)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "START-PROGRAM \"\"\nADD-ONE \"\"\nADD-ONE \"\"\nEND-PROGRAM \"\"\n"
+
+	if actual := tokenLexemeText(tokens); actual != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, actual)
+	}
+
+	if cap(tokens) > 8 {
+		t.Errorf("expected the tokens to grow as they are lexed, got a capacity of %d", cap(tokens))
+	}
+}
@@ -1,6 +1,7 @@
 package dorklang
 
 import (
+	"bytes"
 	"log"
 	"strings"
 )
@@ -94,3 +95,153 @@ func (collection tokenCollection) log() {
 		t.log(0)
 	}
 }
+
+func (collection tokenCollection) matchingSectionEnd(i int) (j int) {
+	depth := 0
+
+	for j = i; j < len(collection); j++ {
+		switch collection[j].lex {
+		case startCommentSectionLexeme,
+			startReadFileSectionLexeme:
+			depth++
+		case endCommentSectionLexeme,
+			endReadFileSectionLexeme:
+			depth--
+		}
+
+		if depth == 0 {
+			return
+		}
+	}
+
+	j = len(collection) - 1
+
+	return
+}
+
+func (collection tokenCollection) sectionPairs() (pairs map[int]int) {
+	pairs = make(map[int]int)
+	starts := make([]int, 0, len(collection)/2)
+
+	for i, t := range collection {
+		// an else section ends the if section that holds it and starts a new section in its place
+		if t.lex == elseSectionLexeme && len(starts) > 0 {
+			j := starts[len(starts)-1]
+
+			switch collection[j].lex {
+			case startIfSectionLexeme,
+				startIfZeroSectionLexeme:
+				pairs[i] = j
+				pairs[j] = i
+				starts[len(starts)-1] = i
+				continue
+			}
+		}
+
+		if t.lex.sectionEndLexeme() != invalidLexeme {
+			starts = append(starts, i)
+			continue
+		}
+
+		if !t.lex.isSection() || len(starts) == 0 {
+			continue
+		}
+
+		j := starts[len(starts)-1]
+		starts = starts[:len(starts)-1]
+
+		if collection[j].lex.sectionEndLexeme() == t.lex {
+			pairs[i] = j
+			pairs[j] = i
+		}
+	}
+
+	return
+}
+
+func (collection tokenCollection) tokenAt(offset int) (index int, found bool) {
+	for i, t := range collection {
+		switch t.lex {
+		case startProgramLexeme,
+			endProgramLexeme,
+			emptyLexeme:
+			continue
+		}
+
+		if t.span.start.Offset <= offset && offset < t.span.end.Offset {
+			index = i
+			found = true
+			return
+		}
+	}
+
+	return
+}
+
+func (collection tokenCollection) usefulTokens() (output tokenCollection) {
+	output = make(tokenCollection, 0, len(collection))
+
+	for _, t := range collection {
+		switch t.lex {
+		case separatorLexeme,
+			emptyLexeme:
+		default:
+			output = append(output, t)
+		}
+	}
+
+	return
+}
+
+func (collection tokenCollection) withoutComments() (output tokenCollection) {
+	output = make(tokenCollection, 0, len(collection))
+
+	for i := 0; i < len(collection); i++ {
+		if collection[i].lex == startCommentSectionLexeme {
+			i = collection.matchingSectionEnd(i)
+			continue
+		}
+
+		output = append(output, collection[i])
+	}
+
+	return
+}
+
+func (collection tokenCollection) equivalent(otherCollection tokenCollection) bool {
+	collection = collection.usefulTokens()
+	otherCollection = otherCollection.usefulTokens()
+
+	if len(collection) != len(otherCollection) {
+		return false
+	}
+
+	for i, t := range collection {
+		t2 := otherCollection[i]
+
+		if t.lex != t2.lex || !bytes.Equal(t.data, t2.data) {
+			return false
+		}
+
+		if t.lex == parentLexeme && !t.childCollection.equivalent(t2.childCollection) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (t token) text() string {
+	switch t.lex {
+	case setLiteralLexeme:
+		return string(t.data)
+	case pushStringLexeme:
+		return stringLiteralStart + string(t.data) + stringLiteralEnd
+	case startProcedureSectionLexeme,
+		callProcedureLexeme,
+		setOverflowModeLexeme:
+		return t.lex.sourceText() + string(t.data)
+	}
+
+	return t.lex.sourceText()
+}
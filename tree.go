@@ -3,24 +3,35 @@ package dorklang
 type tree struct {
 	rootNode             *parentTreeNode
 	interpretCodeOptions InterpretCodeOptions
+	procedures           map[string]*parentTreeNode
+	callDepth            int
+	bigCells             *bigCellTable        // only set in big-integer mode
+	bigRoots             memoryCellCollection // values held by context sections while their bodies run
+	bigCollecting        bool                 // only set on the outermost tree, which can see every value
 }
 
 type treeNode interface {
 	getLexeme() lexeme
 	getTree() *tree
 	getData() []byte
+	getSpan() sourceSpan
 	value(memoryCell) (memoryCell, error)
 }
 
 type defaultTreeNode struct {
-	lexeme lexeme
-	data   []byte
-	tree   *tree
+	lexeme        lexeme
+	data          []byte
+	tree          *tree
+	span          sourceSpan
+	coverageEntry *coverageEntry
+	overflow      OverflowMode // the mode in effect where the command is written
 }
 
 type parentTreeNode struct {
 	defaultTreeNode
-	childNodes []treeNode
+	childNodes     []treeNode
+	endSpan        sourceSpan
+	overflowInside OverflowMode // the mode in effect after the child nodes added so far
 }
 
 type terminalTreeNode struct {
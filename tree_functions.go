@@ -1,5 +1,7 @@
 package dorklang
 
+import "fmt"
+
 func produceTree(input tokenCollection, interpretCodeOptions InterpretCodeOptions) (output *tree, err error) {
 	rootNode := &parentTreeNode{}
 	parentNodeStack := []*parentTreeNode{
@@ -8,10 +10,26 @@ func produceTree(input tokenCollection, interpretCodeOptions InterpretCodeOption
 
 	rootNode.lexeme = startProgramLexeme
 	rootNode.tree = output
+	rootNode.overflow = interpretCodeOptions.Overflow
+	rootNode.overflowInside = interpretCodeOptions.Overflow
 
 	output = new(tree)
 	output.rootNode = rootNode
 	output.interpretCodeOptions = interpretCodeOptions
+	output.procedures = make(map[string]*parentTreeNode)
+
+	if interpretCodeOptions.BigIntegers {
+		if interpretCodeOptions.CellWidth != CellWidth64 {
+			err = fmt.Errorf("%w: %s bits", ErrCellWidthWithBigIntegers, interpretCodeOptions.CellWidth)
+			return
+		}
+
+		if interpretCodeOptions.bigCells == nil {
+			output.interpretCodeOptions.bigCells = newBigCellTable()
+		}
+
+		output.bigCells = output.interpretCodeOptions.bigCells
+	}
 
 	for i := range interpretCodeOptions.saveStacks {
 		if interpretCodeOptions.saveStacks[i] == nil {
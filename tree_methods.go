@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"math/bits"
 	"os"
 	"path/filepath"
 	"time"
@@ -36,8 +37,11 @@ func (tr *tree) addNode(t token, parentNodeStack *[]*parentTreeNode) (err error)
 		endSubtractionSectionLexeme,
 		endMultiplicationSectionLexeme,
 		endDivisionSectionLexeme,
+		endModuloSectionLexeme,
+		endProcedureSectionLexeme,
 		endJumpIfPositiveSectionLexeme,
 		endJumpIfZeroSectionLexeme,
+		endIfSectionLexeme,
 		endReadFileSectionLexeme,
 		endCommentSectionLexeme:
 		{
@@ -47,9 +51,25 @@ func (tr *tree) addNode(t token, parentNodeStack *[]*parentTreeNode) (err error)
 			}
 
 			parentNode := (*parentNodeStack)[len(*parentNodeStack)-1]
-			parentNode.data = t.data
+			if parentNode.lexeme != startProcedureSectionLexeme {
+				parentNode.data = t.data
+			}
+			parentNode.endSpan = t.span
 
 			*parentNodeStack = (*parentNodeStack)[:len(*parentNodeStack)-1]
+
+			// the end of an else section also ends the if section that holds it
+			if parentNode.lexeme == elseSectionLexeme {
+				if len(*parentNodeStack) == 0 {
+					err = ErrTreeParentNodeUnfound
+					return
+				}
+
+				parentNode = (*parentNodeStack)[len(*parentNodeStack)-1]
+				parentNode.endSpan = t.span
+
+				*parentNodeStack = (*parentNodeStack)[:len(*parentNodeStack)-1]
+			}
 		}
 	case parentLexeme:
 		{
@@ -61,6 +81,7 @@ func (tr *tree) addNode(t token, parentNodeStack *[]*parentTreeNode) (err error)
 				defaultTreeNode: defaultTreeNode{
 					lexeme: changeDirLexeme,
 					data:   nextDir,
+					span:   t.span,
 				},
 			}
 
@@ -68,16 +89,23 @@ func (tr *tree) addNode(t token, parentNodeStack *[]*parentTreeNode) (err error)
 				return
 			}
 
+			// a mode set in an included file does not carry over into the file that includes it
+			includingNode := (*parentNodeStack)[len(*parentNodeStack)-1]
+			overflow := includingNode.overflowInside
+
 			for _, t2 := range t.childCollection {
 				if err = tr.addNode(t2, parentNodeStack); err != nil {
 					return
 				}
 			}
 
+			includingNode.overflowInside = overflow
+
 			nextNode = &terminalTreeNode{
 				defaultTreeNode: defaultTreeNode{
 					lexeme: changeDirLexeme,
 					data:   initialDir,
+					span:   t.span,
 				},
 			}
 
@@ -101,6 +129,12 @@ func (tr *tree) addNode(t token, parentNodeStack *[]*parentTreeNode) (err error)
 		lexeme: t.lex,
 		data:   t.data,
 		tree:   tr,
+		span:   t.span,
+	}
+
+	// the overflow mode is taken from the code that surrounds the command, not from the order in which commands are run
+	if len(*parentNodeStack) > 0 {
+		defaultNode.overflow = (*parentNodeStack)[len(*parentNodeStack)-1].overflowInside
 	}
 
 	switch t.lex {
@@ -108,8 +142,13 @@ func (tr *tree) addNode(t token, parentNodeStack *[]*parentTreeNode) (err error)
 		startSubtractionSectionLexeme,
 		startMultiplicationSectionLexeme,
 		startDivisionSectionLexeme,
+		startModuloSectionLexeme,
+		startProcedureSectionLexeme,
 		startJumpIfPositiveSectionLexeme,
 		startJumpIfZeroSectionLexeme,
+		startIfSectionLexeme,
+		startIfZeroSectionLexeme,
+		elseSectionLexeme,
 		startReadFileSectionLexeme,
 		startCommentSectionLexeme:
 		{
@@ -122,7 +161,34 @@ func (tr *tree) addNode(t token, parentNodeStack *[]*parentTreeNode) (err error)
 				return
 			}
 
+			if t.lex == startProcedureSectionLexeme {
+				name := string(t.data)
+
+				if _, found := tr.procedures[name]; found {
+					err = &PositionError{Position: t.span.start, Err: fmt.Errorf("%w: %s", ErrProcedureRedefined, name)}
+					return
+				}
+
+				tr.procedures[name] = nextNode
+			}
+
 			nextParentNode := (*parentNodeStack)[len(*parentNodeStack)-1]
+
+			if t.lex == elseSectionLexeme {
+				switch nextParentNode.lexeme {
+				case startIfSectionLexeme,
+					startIfZeroSectionLexeme:
+				default:
+					err = &PositionError{Position: t.span.start, Err: ErrLexemeSectionStackNoMatch}
+					return
+				}
+
+				// a mode set in the if section does not carry over into the else section
+				nextNode.overflow = nextParentNode.overflow
+			}
+
+			nextNode.overflowInside = nextNode.overflow
+
 			nextParentNode.childNodes = append(nextParentNode.childNodes, nextNode)
 
 			*parentNodeStack = append(*parentNodeStack, nextNode)
@@ -143,8 +209,26 @@ func (tr *tree) addNode(t token, parentNodeStack *[]*parentTreeNode) (err error)
 		divideEightLexeme,
 		divideStackPairLexeme,
 		divideStackWholeLexeme,
+		moduloTwoLexeme,
+		moduloEightLexeme,
+		moduloStackPairLexeme,
+		moduloStackWholeLexeme,
 		squareLexeme,
 		cubeLexeme,
+		bitwiseAndStackPairLexeme,
+		bitwiseAndStackWholeLexeme,
+		bitwiseOrStackPairLexeme,
+		bitwiseOrStackWholeLexeme,
+		bitwiseXorStackPairLexeme,
+		bitwiseXorStackWholeLexeme,
+		bitwiseNotLexeme,
+		bitCountLexeme,
+		shiftLeftOneLexeme,
+		shiftLeftEightLexeme,
+		shiftLeftStackLexeme,
+		shiftRightOneLexeme,
+		shiftRightEightLexeme,
+		shiftRightStackLexeme,
 		setZeroLexeme,
 		setOneByteLexeme,
 		setEightByteLexeme,
@@ -158,13 +242,25 @@ func (tr *tree) addNode(t token, parentNodeStack *[]*parentTreeNode) (err error)
 		setRandomMaxLexeme,
 		setSecondTimestampLexeme,
 		setNanosecondTimestampLexeme,
+		setLiteralLexeme,
 		printCharacterLexeme,
 		printNumberLexeme,
 		inputCharacterLexeme,
 		inputNumberLexeme,
 		logicalAndStackPairLexeme,
 		logicalAndStackWholeLexeme,
+		logicalOrStackPairLexeme,
+		logicalOrStackWholeLexeme,
+		logicalXorStackPairLexeme,
+		logicalXorStackWholeLexeme,
+		equalStackPairLexeme,
+		notEqualStackPairLexeme,
+		lessStackPairLexeme,
+		greaterStackPairLexeme,
+		lessOrEqualStackPairLexeme,
+		greaterOrEqualStackPairLexeme,
 		pushStackLexeme,
+		pushStringLexeme,
 		countStackLexeme,
 		popStackLastLexeme,
 		popStackRandomLexeme,
@@ -178,6 +274,20 @@ func (tr *tree) addNode(t token, parentNodeStack *[]*parentTreeNode) (err error)
 		shuffleStackLexeme,
 		swapStackTopLexeme,
 		reverseStackLexeme,
+		duplicateStackTopLexeme,
+		dropStackTopLexeme,
+		overStackLexeme,
+		rotateStackThreeLexeme,
+		peekStackTopLexeme,
+		pickStackLexeme,
+		rollStackLexeme,
+		giveStackTopLexeme,
+		giveStackManyLexeme,
+		giveStackWholeLexeme,
+		callProcedureLexeme,
+		setOverflowModeLexeme,
+		breakLoopLexeme,
+		continueLoopLexeme,
 		invertLexeme,
 		iotaFromZeroLexeme,
 		iotaFromOneLexeme,
@@ -195,6 +305,14 @@ func (tr *tree) addNode(t token, parentNodeStack *[]*parentTreeNode) (err error)
 			if err = tr.addTerminalNode(nextNode, parentNodeStack); err != nil {
 				return
 			}
+
+			// the mode applies to the rest of the section that holds the directive
+			if t.lex == setOverflowModeLexeme {
+				if nextNode.parentNode.overflowInside, err = ParseOverflowMode(string(t.data)); err != nil {
+					err = &PositionError{Position: t.span.start, Err: err}
+					return
+				}
+			}
 		}
 	default:
 		err = ErrLexemeUnrecognized
@@ -215,11 +333,20 @@ func (tree *tree) Run() (output memoryCell, err error) {
 		return
 	}
 
+	// included files share the table of the outermost file, so their values are passed back as they are
+	if tree.bigMode() && tree.interpretCodeOptions.includeDepth == 0 {
+		tree.bigCollecting = true
+	}
+
 	output, err = tree.rootNode.value(tree.interpretCodeOptions.initialCurrentValue)
 	if err != nil {
 		return
 	}
 
+	if tree.bigCollecting {
+		output = tree.bigCells.uint64Cell(output)
+	}
+
 	err = os.Chdir(initialDir)
 	if err != nil {
 		return
@@ -228,6 +355,40 @@ func (tree *tree) Run() (output memoryCell, err error) {
 	return
 }
 
+func (tree *tree) callProcedure(name string, input memoryCell) (output memoryCell, err error) {
+	output = input
+
+	procedureNode, found := tree.procedures[name]
+	if !found {
+		err = fmt.Errorf("%w: %s", ErrProcedureUnfound, name)
+		return
+	}
+
+	maxCallDepth := tree.interpretCodeOptions.MaxCallDepth
+	if maxCallDepth <= 0 {
+		maxCallDepth = interpretCodeOptionsMaxCallDepthDefault
+	}
+
+	if tree.callDepth >= maxCallDepth {
+		err = fmt.Errorf("%w: the limit of %d levels was exceeded when calling %s", ErrCallDepthExceeded, maxCallDepth, name)
+		return
+	}
+
+	tree.callDepth++
+	defer func() {
+		tree.callDepth--
+	}()
+
+	for _, node := range procedureNode.childNodes {
+		output, err = node.value(output)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
 func (tree *tree) saveStackPtr() (stackPtr *memoryCellCollection, err error) {
 	if tree.interpretCodeOptions.saveStackIndex >= len(tree.interpretCodeOptions.saveStacks) {
 		err = ErrTreeSaveStackIndexInvalid
@@ -239,6 +400,19 @@ func (tree *tree) saveStackPtr() (stackPtr *memoryCellCollection, err error) {
 	return
 }
 
+func (tree *tree) otherSaveStackPtr() (stackPtr *memoryCellCollection, err error) {
+	if tree.interpretCodeOptions.saveStackIndex >= len(tree.interpretCodeOptions.saveStacks) {
+		err = ErrTreeSaveStackIndexInvalid
+		return
+	}
+
+	otherIndex := len(tree.interpretCodeOptions.saveStacks) - 1 - tree.interpretCodeOptions.saveStackIndex
+
+	stackPtr = &tree.interpretCodeOptions.saveStacks[otherIndex]
+
+	return
+}
+
 func (tree *tree) saveStack() (stack memoryCellCollection, err error) {
 	stackPtr, err := tree.saveStackPtr()
 	if err != nil {
@@ -250,6 +424,173 @@ func (tree *tree) saveStack() (stack memoryCellCollection, err error) {
 	return
 }
 
+func (tree *tree) pushRunes(content []byte) (err error) {
+	saveStackPtr, err := tree.saveStackPtr()
+	if err != nil {
+		return
+	}
+	saveStack := *saveStackPtr
+
+	contentRunes := []rune(string(content))
+
+	for i := len(contentRunes) - 1; i >= 0; i-- {
+		if len(saveStack) >= interpretCodeOptionsSaveStackMaxLen {
+			err = ErrTreeSaveStackFull
+			return
+		}
+
+		saveStack = append(saveStack, tree.cellWidth().truncate(memoryCellFromIntegerConstraint(contentRunes[i])))
+	}
+
+	*saveStackPtr = saveStack
+
+	return
+}
+
+func (tree *tree) popStackLast() (value memoryCell, err error) {
+	saveStackPtr, err := tree.saveStackPtr()
+	if err != nil {
+		return
+	}
+	saveStack := *saveStackPtr
+
+	if len(saveStack) == 0 {
+		err = ErrTreeSaveStackEmpty
+		return
+	}
+
+	value = saveStack[len(saveStack)-1]
+
+	*saveStackPtr = saveStack[:len(saveStack)-1]
+
+	return
+}
+
+func (tree *tree) popStackPair() (value1, value2 memoryCell, err error) {
+	saveStackPtr, err := tree.saveStackPtr()
+	if err != nil {
+		return
+	}
+	saveStack := *saveStackPtr
+
+	if len(saveStack) < 2 {
+		err = ErrTreeSaveStackEmpty
+		return
+	}
+
+	value1 = saveStack[len(saveStack)-1]
+	value2 = saveStack[len(saveStack)-2]
+
+	*saveStackPtr = saveStack[:len(saveStack)-2]
+
+	return
+}
+
+// the values that are returned are only valid until the next value is pushed onto the stack
+func (tree *tree) popStackWhole() (values memoryCellCollection, err error) {
+	saveStackPtr, err := tree.saveStackPtr()
+	if err != nil {
+		return
+	}
+	saveStack := *saveStackPtr
+
+	if len(saveStack) == 0 {
+		err = ErrTreeSaveStackEmpty
+		return
+	}
+
+	values = saveStack
+
+	*saveStackPtr = saveStack[:0]
+
+	return
+}
+
+func (tree *tree) cellWidth() CellWidth {
+	if tree == nil {
+		return InterpretCodeDefaultOptions.CellWidth
+	}
+
+	return tree.interpretCodeOptions.CellWidth
+}
+
+func (tree *tree) divisionByZeroMode() DivisionByZeroMode {
+	if tree == nil {
+		return InterpretCodeDefaultOptions.DivisionByZero
+	}
+
+	return tree.interpretCodeOptions.DivisionByZero
+}
+
+// big-integer mode never overflows, so the overflow mode is only used with 64-bit cells
+func (node defaultTreeNode) add(augend, addend memoryCell) (sum memoryCell, err error) {
+	if node.tree.bigMode() {
+		sum = node.tree.bigCells.apply((*big.Int).Add, augend, addend)
+		return
+	}
+
+	sum, err = node.overflow.add(augend, addend, node.tree.cellWidth().max())
+	if err != nil {
+		err = &PositionError{Position: node.span.start, Err: err}
+	}
+
+	return
+}
+
+func (node defaultTreeNode) subtract(minuend, subtrahend memoryCell) (difference memoryCell, err error) {
+	if node.tree.bigMode() {
+		difference = node.tree.bigCells.apply((*big.Int).Sub, minuend, subtrahend)
+		return
+	}
+
+	difference, err = node.overflow.subtract(minuend, subtrahend, node.tree.cellWidth().max())
+	if err != nil {
+		err = &PositionError{Position: node.span.start, Err: err}
+	}
+
+	return
+}
+
+func (node defaultTreeNode) multiply(multiplier, multiplicand memoryCell) (product memoryCell, err error) {
+	if node.tree.bigMode() {
+		product = node.tree.bigCells.apply((*big.Int).Mul, multiplier, multiplicand)
+		return
+	}
+
+	product, err = node.overflow.multiply(multiplier, multiplicand, node.tree.cellWidth().max())
+	if err != nil {
+		err = &PositionError{Position: node.span.start, Err: err}
+	}
+
+	return
+}
+
+func (node defaultTreeNode) divide(dividend, divisor memoryCell) (quotient memoryCell, err error) {
+	if node.tree.bigMode() {
+		quotient, err = node.tree.bigCells.divide(dividend, divisor, node.tree.divisionByZeroMode())
+	} else {
+		quotient, err = node.tree.divisionByZeroMode().divide(dividend, divisor, node.tree.cellWidth().max())
+	}
+	if err != nil {
+		err = &PositionError{Position: node.span.start, Err: err}
+	}
+
+	return
+}
+
+func (node defaultTreeNode) modulo(dividend, divisor memoryCell) (remainder memoryCell, err error) {
+	if node.tree.bigMode() {
+		remainder, err = node.tree.bigCells.modulo(dividend, divisor, node.tree.divisionByZeroMode())
+	} else {
+		remainder, err = node.tree.divisionByZeroMode().modulo(dividend, divisor)
+	}
+	if err != nil {
+		err = &PositionError{Position: node.span.start, Err: err}
+	}
+
+	return
+}
+
 func (node defaultTreeNode) getLexeme() lexeme {
 	return node.lexeme
 }
@@ -262,34 +603,59 @@ func (node defaultTreeNode) getData() []byte {
 	return node.data
 }
 
+func (node defaultTreeNode) getSpan() sourceSpan {
+	return node.span
+}
+
 func (node *parentTreeNode) value(input memoryCell) (output memoryCell, err error) {
 	output = input
 
+	if node.coverageEntry != nil {
+		node.coverageEntry.count++
+	}
+
 	switch node.lexeme {
 	case startJumpIfPositiveSectionLexeme:
 		{
 			for output > 0 {
-				for _, node2 := range node.childNodes {
-					output, err = node2.value(output)
-					if err != nil {
-						return
-					}
+				var broken bool
+
+				output, broken, err = node.loopBodyValue(output)
+				if err != nil || broken {
+					return
 				}
 			}
 		}
 	case startJumpIfZeroSectionLexeme:
 		{
 			for output == 0 {
-				for _, node2 := range node.childNodes {
+				var broken bool
+
+				output, broken, err = node.loopBodyValue(output)
+				if err != nil || broken {
+					return
+				}
+			}
+		}
+	case startIfSectionLexeme,
+		startIfZeroSectionLexeme:
+		{
+			bodyNodes, elseNode := node.ifBranches()
+
+			if (output != 0) == (node.lexeme == startIfSectionLexeme) {
+				for _, node2 := range bodyNodes {
 					output, err = node2.value(output)
 					if err != nil {
 						return
 					}
 				}
+			} else if elseNode != nil {
+				output, err = elseNode.value(output)
 			}
 		}
 	case startProgramLexeme,
-		startReadFileSectionLexeme:
+		startReadFileSectionLexeme,
+		elseSectionLexeme:
 		{
 			for _, node2 := range node.childNodes {
 				output, err = node2.value(output)
@@ -301,32 +667,44 @@ func (node *parentTreeNode) value(input memoryCell) (output memoryCell, err erro
 	case startAdditionSectionLexeme,
 		startSubtractionSectionLexeme,
 		startMultiplicationSectionLexeme,
-		startDivisionSectionLexeme:
+		startDivisionSectionLexeme,
+		startModuloSectionLexeme:
 		{
 			var localOutput memoryCell
 
+			// the outer value cannot be seen while the children run, so it is kept where big cells are collected from
+			bigRootIndex := node.tree.pushBigRoot(output)
+
 			for _, node2 := range node.childNodes {
 				localOutput, err = node2.value(localOutput)
 				if err != nil {
-					return
+					break
 				}
 			}
 
+			output = node.tree.popBigRoot(bigRootIndex, output)
+			if err != nil {
+				return
+			}
+
 			switch node.lexeme {
 			case startAdditionSectionLexeme:
-				output += localOutput
+				output, err = node.add(output, localOutput)
 			case startSubtractionSectionLexeme:
-				output -= localOutput
+				output, err = node.subtract(output, localOutput)
 			case startMultiplicationSectionLexeme:
-				output *= localOutput
+				output, err = node.multiply(output, localOutput)
 			case startDivisionSectionLexeme:
-				output /= localOutput
+				output, err = node.divide(output, localOutput)
+			case startModuloSectionLexeme:
+				output, err = node.modulo(output, localOutput)
 			default:
 				err = ErrLexemeUnrecognized
 				return
 			}
 		}
-	case startCommentSectionLexeme:
+	case startCommentSectionLexeme,
+		startProcedureSectionLexeme: // the body of a procedure only runs when it is called
 	default:
 		err = ErrLexemeUnrecognized
 	}
@@ -334,14 +712,78 @@ func (node *parentTreeNode) value(input memoryCell) (output memoryCell, err erro
 	return
 }
 
+// break and continue commands return errors that are passed up through any sections until they reach a loop
+func (node *parentTreeNode) loopBodyValue(input memoryCell) (output memoryCell, broken bool, err error) {
+	output = input
+
+	for _, node2 := range node.childNodes {
+		output, err = node2.value(output)
+		if err != nil {
+			break
+		}
+	}
+
+	switch err {
+	case errLoopBreak:
+		broken = true
+		err = nil
+	case errLoopContinue:
+		err = nil
+	}
+
+	return
+}
+
+// an else section, if there is one, is always the last child of its if section
+func (node *parentTreeNode) ifBranches() (bodyNodes []treeNode, elseNode *parentTreeNode) {
+	bodyNodes = node.childNodes
+
+	if len(bodyNodes) > 0 {
+		if lastNode, ok := bodyNodes[len(bodyNodes)-1].(*parentTreeNode); ok && lastNode.lexeme == elseSectionLexeme {
+			bodyNodes = bodyNodes[:len(bodyNodes)-1]
+			elseNode = lastNode
+		}
+	}
+
+	return
+}
+
 func (node *terminalTreeNode) value(input memoryCell) (output memoryCell, err error) {
 	output = input
 
+	if node.coverageEntry != nil {
+		node.coverageEntry.count++
+	}
+
+	if node.tree.bigMode() {
+		output = node.tree.collectBigCells(output)
+
+		var handled bool
+		output, handled, err = node.bigValue(output)
+		if handled || err != nil {
+			return
+		}
+	}
+
+	output, err = node.fixedValue(output)
+	if err != nil {
+		return
+	}
+
+	// whatever a command sets the current value to, including constants, it is kept within the width of a cell
+	output = node.tree.cellWidth().truncate(output)
+
+	return
+}
+
+func (node *terminalTreeNode) fixedValue(input memoryCell) (output memoryCell, err error) {
+	output = input
+
 	switch node.lexeme {
 	case addOneLexeme:
-		output++
+		output, err = node.add(output, 1)
 	case addEightLexeme:
-		output += 8
+		output, err = node.add(output, 8)
 	case addStackPairLexeme:
 		{
 			if node.tree == nil {
@@ -366,7 +808,7 @@ func (node *terminalTreeNode) value(input memoryCell) (output memoryCell, err er
 
 			*saveStackPtr = saveStack[:len(saveStack)-2]
 
-			output = augend + addend
+			output, err = node.add(augend, addend)
 		}
 	case addStackWholeLexeme:
 		{
@@ -390,7 +832,10 @@ func (node *terminalTreeNode) value(input memoryCell) (output memoryCell, err er
 			var sum memoryCell
 
 			for i := len(saveStack) - 1; i >= 0; i-- {
-				sum += saveStack[i]
+				sum, err = node.add(sum, saveStack[i])
+				if err != nil {
+					return
+				}
 			}
 
 			*saveStackPtr = saveStack[:0]
@@ -398,9 +843,9 @@ func (node *terminalTreeNode) value(input memoryCell) (output memoryCell, err er
 			output = sum
 		}
 	case subtractOneLexeme:
-		output--
+		output, err = node.subtract(output, 1)
 	case subtractEightLexeme:
-		output -= 8
+		output, err = node.subtract(output, 8)
 	case subtractStackPairLexeme:
 		{
 			if node.tree == nil {
@@ -425,7 +870,7 @@ func (node *terminalTreeNode) value(input memoryCell) (output memoryCell, err er
 
 			*saveStackPtr = saveStack[:len(saveStack)-2]
 
-			output = minuend - subtrahend
+			output, err = node.subtract(minuend, subtrahend)
 		}
 	case subtractStackWholeLexeme:
 		{
@@ -449,7 +894,10 @@ func (node *terminalTreeNode) value(input memoryCell) (output memoryCell, err er
 			subtraction := saveStack[len(saveStack)-1]
 
 			for i := len(saveStack) - 2; i >= 0; i-- {
-				subtraction -= saveStack[i]
+				subtraction, err = node.subtract(subtraction, saveStack[i])
+				if err != nil {
+					return
+				}
 			}
 
 			*saveStackPtr = saveStack[:0]
@@ -457,9 +905,9 @@ func (node *terminalTreeNode) value(input memoryCell) (output memoryCell, err er
 			output = subtraction
 		}
 	case multiplyTwoLexeme:
-		output *= 2
+		output, err = node.multiply(output, 2)
 	case multiplyEightLexeme:
-		output *= 8
+		output, err = node.multiply(output, 8)
 	case multiplyStackPairLexeme:
 		{
 			if node.tree == nil {
@@ -484,7 +932,7 @@ func (node *terminalTreeNode) value(input memoryCell) (output memoryCell, err er
 
 			*saveStackPtr = saveStack[:len(saveStack)-2]
 
-			output = multiplier * multiplicand
+			output, err = node.multiply(multiplier, multiplicand)
 		}
 	case multiplyStackWholeLexeme:
 		{
@@ -508,7 +956,10 @@ func (node *terminalTreeNode) value(input memoryCell) (output memoryCell, err er
 			product := saveStack[len(saveStack)-1]
 
 			for i := len(saveStack) - 2; i >= 0; i-- {
-				product *= saveStack[i]
+				product, err = node.multiply(product, saveStack[i])
+				if err != nil {
+					return
+				}
 			}
 
 			*saveStackPtr = saveStack[:0]
@@ -516,9 +967,9 @@ func (node *terminalTreeNode) value(input memoryCell) (output memoryCell, err er
 			output = product
 		}
 	case divideTwoLexeme:
-		output /= 2
+		output, err = node.divide(output, 2)
 	case divideEightLexeme:
-		output /= 8
+		output, err = node.divide(output, 8)
 	case divideStackPairLexeme:
 		{
 			if node.tree == nil {
@@ -543,7 +994,7 @@ func (node *terminalTreeNode) value(input memoryCell) (output memoryCell, err er
 
 			*saveStackPtr = saveStack[:len(saveStack)-2]
 
-			output = dividend / divisor
+			output, err = node.divide(dividend, divisor)
 		}
 	case divideStackWholeLexeme:
 		{
@@ -567,17 +1018,91 @@ func (node *terminalTreeNode) value(input memoryCell) (output memoryCell, err er
 			division := saveStack[len(saveStack)-1]
 
 			for i := len(saveStack) - 2; i >= 0; i-- {
-				division %= saveStack[i]
+				division, err = node.divide(division, saveStack[i])
+				if err != nil {
+					return
+				}
 			}
 
 			*saveStackPtr = saveStack[:0]
 
 			output = division
 		}
+	case moduloTwoLexeme:
+		output, err = node.modulo(output, 2)
+	case moduloEightLexeme:
+		output, err = node.modulo(output, 8)
+	case moduloStackPairLexeme:
+		{
+			if node.tree == nil {
+				err = ErrTreeUnfound
+				return
+			}
+
+			var saveStackPtr *memoryCellCollection
+			saveStackPtr, err = node.tree.saveStackPtr()
+			if err != nil {
+				return
+			}
+			saveStack := *saveStackPtr
+
+			if len(saveStack) < 2 {
+				err = ErrTreeSaveStackEmpty
+				return
+			}
+
+			dividend := saveStack[len(saveStack)-1]
+			divisor := saveStack[len(saveStack)-2]
+
+			*saveStackPtr = saveStack[:len(saveStack)-2]
+
+			output, err = node.modulo(dividend, divisor)
+		}
+	case moduloStackWholeLexeme:
+		{
+			if node.tree == nil {
+				err = ErrTreeUnfound
+				return
+			}
+
+			var saveStackPtr *memoryCellCollection
+			saveStackPtr, err = node.tree.saveStackPtr()
+			if err != nil {
+				return
+			}
+			saveStack := *saveStackPtr
+
+			if len(saveStack) == 0 {
+				err = ErrTreeSaveStackEmpty
+				return
+			}
+
+			remainder := saveStack[len(saveStack)-1]
+
+			for i := len(saveStack) - 2; i >= 0; i-- {
+				remainder, err = node.modulo(remainder, saveStack[i])
+				if err != nil {
+					return
+				}
+			}
+
+			*saveStackPtr = saveStack[:0]
+
+			output = remainder
+		}
 	case squareLexeme:
-		output *= output
+		output, err = node.multiply(output, output)
 	case cubeLexeme:
-		output *= output * output
+		{
+			var square memoryCell
+
+			square, err = node.multiply(output, output)
+			if err != nil {
+				return
+			}
+
+			output, err = node.multiply(square, output)
+		}
 	case printCharacterLexeme:
 		{
 			if node.tree == nil {
@@ -685,6 +1210,185 @@ func (node *terminalTreeNode) value(input memoryCell) (output memoryCell, err er
 				output = 0
 			}
 		}
+	case logicalOrStackPairLexeme,
+		logicalXorStackPairLexeme,
+		equalStackPairLexeme,
+		notEqualStackPairLexeme,
+		lessStackPairLexeme,
+		greaterStackPairLexeme,
+		lessOrEqualStackPairLexeme,
+		greaterOrEqualStackPairLexeme:
+		{
+			if node.tree == nil {
+				err = ErrTreeUnfound
+				return
+			}
+
+			var saveStack memoryCellCollection
+			saveStack, err = node.tree.saveStack()
+			if err != nil {
+				return
+			}
+
+			if len(saveStack) < 2 {
+				err = ErrTreeSaveStackEmpty
+				return
+			}
+
+			value1 := saveStack[len(saveStack)-1]
+			value2 := saveStack[len(saveStack)-2]
+
+			var result bool
+
+			switch node.lexeme {
+			case logicalOrStackPairLexeme:
+				result = value1 > 0 || value2 > 0
+			case logicalXorStackPairLexeme:
+				result = (value1 > 0) != (value2 > 0)
+			case equalStackPairLexeme:
+				result = value1 == value2
+			case notEqualStackPairLexeme:
+				result = value1 != value2
+			case lessStackPairLexeme:
+				result = value1 < value2
+			case greaterStackPairLexeme:
+				result = value1 > value2
+			case lessOrEqualStackPairLexeme:
+				result = value1 <= value2
+			case greaterOrEqualStackPairLexeme:
+				result = value1 >= value2
+			}
+
+			if result {
+				output = 1
+			} else {
+				output = 0
+			}
+		}
+	case logicalOrStackWholeLexeme,
+		logicalXorStackWholeLexeme:
+		{
+			if node.tree == nil {
+				err = ErrTreeUnfound
+				return
+			}
+
+			var saveStack memoryCellCollection
+			saveStack, err = node.tree.saveStack()
+			if err != nil {
+				return
+			}
+
+			if len(saveStack) == 0 {
+				err = ErrTreeSaveStackEmpty
+				return
+			}
+
+			var result bool
+
+			for i := len(saveStack) - 1; i >= 0; i-- {
+				if node.lexeme == logicalOrStackWholeLexeme {
+					if saveStack[i] > 0 {
+						result = true
+						break
+					}
+				} else if saveStack[i] > 0 {
+					result = !result
+				}
+			}
+
+			if result {
+				output = 1
+			} else {
+				output = 0
+			}
+		}
+	case bitwiseAndStackPairLexeme,
+		bitwiseOrStackPairLexeme,
+		bitwiseXorStackPairLexeme:
+		{
+			if node.tree == nil {
+				err = ErrTreeUnfound
+				return
+			}
+
+			var value1, value2 memoryCell
+			value1, value2, err = node.tree.popStackPair()
+			if err != nil {
+				return
+			}
+
+			switch node.lexeme {
+			case bitwiseAndStackPairLexeme:
+				output = value1 & value2
+			case bitwiseOrStackPairLexeme:
+				output = value1 | value2
+			case bitwiseXorStackPairLexeme:
+				output = value1 ^ value2
+			}
+		}
+	case bitwiseAndStackWholeLexeme,
+		bitwiseOrStackWholeLexeme,
+		bitwiseXorStackWholeLexeme:
+		{
+			if node.tree == nil {
+				err = ErrTreeUnfound
+				return
+			}
+
+			var values memoryCellCollection
+			values, err = node.tree.popStackWhole()
+			if err != nil {
+				return
+			}
+
+			result := values[len(values)-1]
+
+			for i := len(values) - 2; i >= 0; i-- {
+				switch node.lexeme {
+				case bitwiseAndStackWholeLexeme:
+					result &= values[i]
+				case bitwiseOrStackWholeLexeme:
+					result |= values[i]
+				case bitwiseXorStackWholeLexeme:
+					result ^= values[i]
+				}
+			}
+
+			output = result
+		}
+	case bitwiseNotLexeme:
+		output = ^output
+	case bitCountLexeme:
+		output = memoryCellFromIntegerConstraint(bits.OnesCount64(output.Uint64()))
+	case shiftLeftOneLexeme:
+		output <<= 1
+	case shiftLeftEightLexeme:
+		output <<= 8
+	case shiftRightOneLexeme:
+		output >>= 1
+	case shiftRightEightLexeme:
+		output >>= 8
+	case shiftLeftStackLexeme,
+		shiftRightStackLexeme:
+		{
+			if node.tree == nil {
+				err = ErrTreeUnfound
+				return
+			}
+
+			var shift memoryCell
+			shift, err = node.tree.popStackLast()
+			if err != nil {
+				return
+			}
+
+			if node.lexeme == shiftLeftStackLexeme {
+				output <<= shift
+			} else {
+				output >>= shift
+			}
+		}
 	case setZeroLexeme:
 		output = 0
 	case setOneByteLexeme:
@@ -703,6 +1407,8 @@ func (node *terminalTreeNode) value(input memoryCell) (output memoryCell, err er
 		output = 1 << 33 // 8_589_934_592
 	case setEightGibibyteLexeme:
 		output = 1 << 36 // 68_719_476_736
+	case setLiteralLexeme:
+		output, err = parseNumericLiteral(node.data)
 	case setRandomByteLexeme:
 		{
 			b := make([]byte, 1)
@@ -785,6 +1491,21 @@ func (node *terminalTreeNode) value(input memoryCell) (output memoryCell, err er
 
 			*saveStackPtr = append(saveStack, output)
 		}
+	case pushStringLexeme:
+		{
+			if node.tree == nil {
+				err = ErrTreeUnfound
+				return
+			}
+
+			var content []byte
+			content, err = decodeStringLiteral(node.data)
+			if err != nil {
+				return
+			}
+
+			err = node.tree.pushRunes(content)
+		}
 	case countStackLexeme:
 		{
 			if node.tree == nil {
@@ -910,7 +1631,7 @@ func (node *terminalTreeNode) value(input memoryCell) (output memoryCell, err er
 					return
 				}
 
-				saveStack = append(saveStack, memoryCellFromIntegerConstraint(value))
+				saveStack = append(saveStack, node.tree.cellWidth().truncate(memoryCellFromIntegerConstraint(value)))
 			}
 
 			*saveStackPtr = saveStack
@@ -1064,6 +1785,145 @@ func (node *terminalTreeNode) value(input memoryCell) (output memoryCell, err er
 
 			saveStack.Reverse()
 		}
+	case duplicateStackTopLexeme,
+		overStackLexeme,
+		pickStackLexeme:
+		{
+			if node.tree == nil {
+				err = ErrTreeUnfound
+				return
+			}
+
+			var saveStackPtr *memoryCellCollection
+			saveStackPtr, err = node.tree.saveStackPtr()
+			if err != nil {
+				return
+			}
+
+			if len(*saveStackPtr) >= interpretCodeOptionsSaveStackMaxLen {
+				err = ErrTreeSaveStackFull
+				return
+			}
+
+			var depth memoryCell
+			switch node.lexeme {
+			case overStackLexeme:
+				depth = 1
+			case pickStackLexeme:
+				depth = output
+			}
+
+			var saveStack memoryCellCollection
+			saveStack, err = saveStackPtr.Pick(depth)
+			if err != nil {
+				return
+			}
+
+			*saveStackPtr = saveStack
+		}
+	case dropStackTopLexeme:
+		{
+			if node.tree == nil {
+				err = ErrTreeUnfound
+				return
+			}
+
+			_, err = node.tree.popStackLast()
+		}
+	case rotateStackThreeLexeme,
+		rollStackLexeme:
+		{
+			if node.tree == nil {
+				err = ErrTreeUnfound
+				return
+			}
+
+			var saveStack memoryCellCollection
+			saveStack, err = node.tree.saveStack()
+			if err != nil {
+				return
+			}
+
+			depth := output
+			if node.lexeme == rotateStackThreeLexeme {
+				depth = 2
+			}
+
+			err = saveStack.Roll(depth)
+		}
+	case giveStackTopLexeme,
+		giveStackManyLexeme,
+		giveStackWholeLexeme:
+		{
+			if node.tree == nil {
+				err = ErrTreeUnfound
+				return
+			}
+
+			var saveStackPtr, otherSaveStackPtr *memoryCellCollection
+			saveStackPtr, err = node.tree.saveStackPtr()
+			if err != nil {
+				return
+			}
+			otherSaveStackPtr, err = node.tree.otherSaveStackPtr()
+			if err != nil {
+				return
+			}
+			saveStack := *saveStackPtr
+
+			count := memoryCellFromIntegerConstraint(len(saveStack))
+			switch node.lexeme {
+			case giveStackTopLexeme:
+				count = 1
+			case giveStackManyLexeme:
+				count = output
+			}
+
+			if count > memoryCellFromIntegerConstraint(len(saveStack)) {
+				err = ErrTreeSaveStackEmpty
+				return
+			}
+
+			if memoryCellFromIntegerConstraint(len(*otherSaveStackPtr))+count > interpretCodeOptionsSaveStackMaxLen {
+				err = ErrTreeSaveStackFull
+				return
+			}
+
+			i := len(saveStack) - int(count)
+
+			*otherSaveStackPtr = append(*otherSaveStackPtr, saveStack[i:]...)
+			*saveStackPtr = saveStack[:i]
+		}
+	case callProcedureLexeme:
+		{
+			if node.tree == nil {
+				err = ErrTreeUnfound
+				return
+			}
+
+			output, err = node.tree.callProcedure(string(node.data), output)
+		}
+	case setOverflowModeLexeme:
+		// the mode was already given to the commands that follow when the tree was built
+	case breakLoopLexeme:
+		err = errLoopBreak
+	case continueLoopLexeme:
+		err = errLoopContinue
+	case peekStackTopLexeme:
+		{
+			if node.tree == nil {
+				err = ErrTreeUnfound
+				return
+			}
+
+			var saveStack memoryCellCollection
+			saveStack, err = node.tree.saveStack()
+			if err != nil {
+				return
+			}
+
+			output, err = saveStack.Peek(0)
+		}
 	case iotaFromZeroLexeme:
 		{
 			if node.tree == nil {
@@ -1196,9 +2056,14 @@ func (node *terminalTreeNode) value(input memoryCell) (output memoryCell, err er
 			switch fileExt {
 			case FileExtensionForCode:
 				{
-					interpretCodeOptionsCloned := tree.interpretCodeOptions.Clone()
+					var interpretCodeOptionsCloned InterpretCodeOptions
+					interpretCodeOptionsCloned, err = tree.interpretCodeOptions.withInclude(fileAbsPath)
+					if err != nil {
+						return
+					}
 
 					interpretCodeOptionsCloned.WorkingDir = filepath.Dir(fileAbsPath)
+					interpretCodeOptionsCloned.Overflow = node.overflow
 					interpretCodeOptionsCloned.initialCurrentValue = output
 
 					var outputUint64 uint64
@@ -1211,27 +2076,7 @@ func (node *terminalTreeNode) value(input memoryCell) (output memoryCell, err er
 					output = memoryCellFromIntegerConstraint(outputUint64)
 				}
 			default:
-				{
-					var saveStackPtr *memoryCellCollection
-					saveStackPtr, err = tree.saveStackPtr()
-					if err != nil {
-						return
-					}
-					saveStack := *saveStackPtr
-
-					contentRunes := []rune(string(content))
-
-					for i := len(contentRunes) - 1; i >= 0; i-- {
-						if len(saveStack) >= interpretCodeOptionsSaveStackMaxLen {
-							err = ErrTreeSaveStackFull
-							return
-						}
-
-						saveStack = append(saveStack, memoryCellFromIntegerConstraint(contentRunes[i]))
-					}
-
-					*saveStackPtr = saveStack
-				}
+				err = tree.pushRunes(content)
 			}
 		}
 	case changeDirLexeme:
@@ -0,0 +1,348 @@
+package dorklang
+
+import (
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestOverflowModeIsLexical(t *testing.T) {
+	includedFiles := map[string]string{
+		"saturate.dork": "%#saturate",
+		"subtract.dork": "0 -",
+	}
+
+	testCases := []struct {
+		name     string
+		source   string
+		expected uint64
+	}{
+		{"directive", "%#saturate 0 -", 0},
+		{"after a taken if section", "+ %? %#saturate %. 0 -", math.MaxUint64},
+		{"inside a taken if section", "+ %? %#saturate 0 - %.", 0},
+		{"else section", "%? %#saturate %! 0 - %.", math.MaxUint64},
+		{"after a loop", "+ < %#saturate %b > 0 -", math.MaxUint64},
+		{"after a procedure call", "%(p %#saturate %) &p 0 -", math.MaxUint64},
+		{"procedure defined in a mode", "%#saturate %(p 0 - %) %#wrap &p", 0},
+		{"procedure called in a mode", "%(p 0 - %) %#saturate &p", math.MaxUint64},
+		{"after an included file", "{{saturate.dork}} 0 -", math.MaxUint64},
+		{"inside an included file", "%#saturate {{subtract.dork}}", 0},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			workingDir := t.TempDir()
+
+			for name, content := range includedFiles {
+				if err := os.WriteFile(filepath.Join(workingDir, name), []byte(content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			initialDir, err := os.Getwd()
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() {
+				os.Chdir(initialDir)
+			})
+
+			options := InterpretCodeDefaultOptions.Clone()
+			options.WorkingDir = workingDir
+			options.Input = strings.NewReader("")
+
+			output, err := InterpretCode([]byte(testCase.source), options)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if output != testCase.expected {
+				t.Errorf("expected %d, got %d", testCase.expected, output)
+			}
+		})
+	}
+}
+
+func interpretTestSource(t *testing.T, source string, options InterpretCodeOptions) (output uint64, err error) {
+	t.Helper()
+
+	initialDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		os.Chdir(initialDir)
+	})
+
+	options.WorkingDir = t.TempDir()
+	options.Input = strings.NewReader("")
+	options.Output = &strings.Builder{}
+
+	output, err = InterpretCode([]byte(source), options)
+
+	return
+}
+
+func TestDivisionAndModuloCommands(t *testing.T) {
+	testCases := []struct {
+		name     string
+		source   string
+		expected uint64
+	}{
+		{"divide pair", "3 : 100 : %/", 33},
+		{"divide whole stack", "2 : 3 : 100 : %//", 16},
+		{"divide whole stack empties it", "2 : 3 : 100 : %// %:", 0},
+		{"modulo two", "7 m", 1},
+		{"modulo eight", "13 mm", 5},
+		{"modulo two twice", "13 m m", 1},
+		{"modulo pair", "3 : 100 : %m", 1},
+		{"modulo whole stack", "7 : 30 : 100 : %mm", 3},
+		{"modulo whole stack empties it", "7 : 30 : 100 : %mm %:", 0},
+		{"modulo section", "100 %[ 7 %]", 2},
+		{"modulo section then add", "100 %[ 3 %] +", 2},
+		{"division section", "100 [[ 7 ]]", 14},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			output, err := interpretTestSource(t, testCase.source, InterpretCodeDefaultOptions.Clone())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if output != testCase.expected {
+				t.Errorf("expected %d, got %d", testCase.expected, output)
+			}
+		})
+	}
+}
+
+func TestDivisionByZero(t *testing.T) {
+	testCases := []struct {
+		name     string
+		source   string
+		position Position
+		zero     uint64
+		max      uint64
+	}{
+		{"divide pair", "0 : 7 : %/", Position{Offset: 8, Line: 1, Column: 9}, 0, math.MaxUint64},
+		{"divide whole stack", "3 : 0 : 7 : %//", Position{Offset: 12, Line: 1, Column: 13}, 0, math.MaxUint64 / 3},
+		{"modulo pair", "0 : 7 : %m", Position{Offset: 8, Line: 1, Column: 9}, 7, 7},
+		{"modulo whole stack", "4 : 0 : 7 : %mm", Position{Offset: 12, Line: 1, Column: 13}, 3, 3},
+		{"division section", "+\n7 [[ ~ ]]", Position{Offset: 4, Line: 2, Column: 3}, 0, math.MaxUint64},
+		{"modulo section", "+\n7 %[ ~ %]", Position{Offset: 4, Line: 2, Column: 3}, 7, 7},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := interpretTestSource(t, testCase.source, InterpretCodeDefaultOptions.Clone())
+			if !errors.Is(err, ErrDivisionByZero) {
+				t.Fatalf("expected %v, got %v", ErrDivisionByZero, err)
+			}
+
+			var positionErr *PositionError
+			if !errors.As(err, &positionErr) {
+				t.Fatalf("expected a position, got %v", err)
+			}
+
+			if positionErr.Position != testCase.position {
+				t.Errorf("expected position %+v, got %+v", testCase.position, positionErr.Position)
+			}
+
+			for mode, expected := range map[DivisionByZeroMode]uint64{
+				DivisionByZeroZero: testCase.zero,
+				DivisionByZeroMax:  testCase.max,
+			} {
+				options := InterpretCodeDefaultOptions.Clone()
+				options.DivisionByZero = mode
+
+				output, err := interpretTestSource(t, testCase.source, options)
+				if err != nil {
+					t.Fatalf("%s: %v", mode, err)
+				}
+
+				if output != expected {
+					t.Errorf("%s: expected %d, got %d", mode, expected, output)
+				}
+			}
+		})
+	}
+}
+
+func TestDivisionByZeroMaxWithCellWidth(t *testing.T) {
+	testCases := []struct {
+		cellWidth CellWidth
+		expected  uint64
+	}{
+		{CellWidth8, math.MaxUint8},
+		{CellWidth16, math.MaxUint16},
+		{CellWidth32, math.MaxUint32},
+		{CellWidth64, math.MaxUint64},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.cellWidth.String(), func(t *testing.T) {
+			for _, source := range []string{"0 : 7 : %/", "7 [[ ~ ]]"} {
+				options := InterpretCodeDefaultOptions.Clone()
+				options.DivisionByZero = DivisionByZeroMax
+				options.CellWidth = testCase.cellWidth
+
+				output, err := interpretTestSource(t, source, options)
+				if err != nil {
+					t.Fatalf("%s: %v", source, err)
+				}
+
+				if output != testCase.expected {
+					t.Errorf("%s: expected %d, got %d", source, testCase.expected, output)
+				}
+			}
+		})
+	}
+}
+
+func TestDivisionByZeroWithBigIntegers(t *testing.T) {
+	for mode, fails := range map[DivisionByZeroMode]bool{
+		DivisionByZeroError: true,
+		DivisionByZeroZero:  false,
+		DivisionByZeroMax:   true,
+	} {
+		t.Run(mode.String(), func(t *testing.T) {
+			options := InterpretCodeDefaultOptions.Clone()
+			options.DivisionByZero = mode
+			options.BigIntegers = true
+
+			output, err := interpretTestSource(t, "0 : 7 : %/", options)
+			if fails {
+				if !errors.Is(err, ErrDivisionByZero) {
+					t.Errorf("expected %v, got %v", ErrDivisionByZero, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if output != 0 {
+				t.Errorf("expected 0, got %d", output)
+			}
+		})
+	}
+}
+
+func TestCellWidth(t *testing.T) {
+	testCases := []struct {
+		name      string
+		source    string
+		cellWidth CellWidth
+		overflow  OverflowMode
+		expected  uint64
+	}{
+		{"wrap past the maximum at 8 bits", "255 +", CellWidth8, OverflowWrap, 0},
+		{"wrap below zero at 8 bits", "0 -", CellWidth8, OverflowWrap, math.MaxUint8},
+		{"wrap a square at 8 bits", "17 ^", CellWidth8, OverflowWrap, 289 - 256},
+		{"wrap a stack sum at 8 bits", "200 : 100 : %+", CellWidth8, OverflowWrap, 44},
+		{"wrap a section at 8 bits", "200 (100)", CellWidth8, OverflowWrap, 44},
+		{"wrap past the maximum at 16 bits", "65535 +", CellWidth16, OverflowWrap, 0},
+		{"wrap below zero at 16 bits", "0 -", CellWidth16, OverflowWrap, math.MaxUint16},
+		{"wrap a square at 16 bits", "257 ^", CellWidth16, OverflowWrap, 513},
+		{"wrap below zero at 32 bits", "0 -", CellWidth32, OverflowWrap, math.MaxUint32},
+		{"narrow a constant at 8 bits", `%""`, CellWidth8, OverflowWrap, 0},
+		{"narrow a constant at 16 bits", `""`, CellWidth16, OverflowWrap, 0},
+		{"keep a constant at 32 bits", `""`, CellWidth32, OverflowWrap, 65536},
+		{"narrow a constant at 32 bits", `%""`, CellWidth32, OverflowWrap, 0},
+		{"keep a constant at 64 bits", `%""`, CellWidth64, OverflowWrap, 68719476736},
+		{"narrow a literal at 8 bits", "300", CellWidth8, OverflowWrap, 44},
+		{"narrow a hexadecimal literal at 8 bits", "0x1ff", CellWidth8, OverflowWrap, math.MaxUint8},
+		{"narrow a literal at 16 bits", "70000", CellWidth16, OverflowWrap, 4464},
+		{"narrow a pushed character at 8 bits", "%{Ł};", CellWidth8, OverflowWrap, 0x41},
+		{"saturate past the maximum at 8 bits", "255 +", CellWidth8, OverflowSaturate, math.MaxUint8},
+		{"saturate below zero at 8 bits", "0 -", CellWidth8, OverflowSaturate, 0},
+		{"saturate a product at 8 bits", "200 *", CellWidth8, OverflowSaturate, math.MaxUint8},
+		{"saturate a directive at 8 bits", "%#saturate 250 ++", CellWidth8, OverflowWrap, math.MaxUint8},
+		{"saturate past the maximum at 16 bits", "65535 ++", CellWidth16, OverflowSaturate, math.MaxUint16},
+		{"reach the maximum at 16 bits", "65527 ++", CellWidth16, OverflowSaturate, math.MaxUint16},
+		{"saturate only past the maximum at 64 bits", "255 +", CellWidth64, OverflowSaturate, 256},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			options := InterpretCodeDefaultOptions.Clone()
+			options.CellWidth = testCase.cellWidth
+			options.Overflow = testCase.overflow
+
+			output, err := interpretTestSource(t, testCase.source, options)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if output != testCase.expected {
+				t.Errorf("expected %d, got %d", testCase.expected, output)
+			}
+		})
+	}
+}
+
+func TestCellWidthTrap(t *testing.T) {
+	testCases := []struct {
+		source   string
+		position Position
+	}{
+		{"255 +", Position{Offset: 4, Line: 1, Column: 5}},
+		{"0\n-", Position{Offset: 2, Line: 2, Column: 1}},
+		{"16 ^", Position{Offset: 3, Line: 1, Column: 4}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.source, func(t *testing.T) {
+			options := InterpretCodeDefaultOptions.Clone()
+			options.CellWidth = CellWidth8
+			options.Overflow = OverflowTrap
+
+			_, err := interpretTestSource(t, testCase.source, options)
+			if !errors.Is(err, ErrOverflow) {
+				t.Fatalf("expected %v, got %v", ErrOverflow, err)
+			}
+
+			var positionErr *PositionError
+			if !errors.As(err, &positionErr) {
+				t.Fatalf("expected a position, got %v", err)
+			}
+
+			if positionErr.Position != testCase.position {
+				t.Errorf("expected position %+v, got %+v", testCase.position, positionErr.Position)
+			}
+		})
+	}
+}
+
+func TestCellWidthWithBigIntegers(t *testing.T) {
+	for _, cellWidth := range []CellWidth{CellWidth8, CellWidth16, CellWidth32, CellWidth64} {
+		t.Run(cellWidth.String(), func(t *testing.T) {
+			options := InterpretCodeDefaultOptions.Clone()
+			options.CellWidth = cellWidth
+			options.BigIntegers = true
+
+			output, err := interpretTestSource(t, "255 +", options)
+			if cellWidth != CellWidth64 {
+				if !errors.Is(err, ErrCellWidthWithBigIntegers) {
+					t.Errorf("expected %v, got %v", ErrCellWidthWithBigIntegers, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if output != 256 {
+				t.Errorf("expected 256, got %d", output)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package dorklang
+
+type vetAnalysis struct {
+	diagnostics     DiagnosticCollection
+	diagnosticsSeen map[Diagnostic]bool
+	loops           []*vetLoopJumps
+}
+
+// the ways in which break and continue commands leave the body of a loop
+type vetLoopJumps struct {
+	breakFound    bool
+	continueState vetState
+	continueFound bool
+}
+
+type vetState struct {
+	value               memoryCell
+	valueKnown          bool
+	valueNonZero        bool // only used when the value is not known
+	unreachable         bool
+	unreachableReported bool
+}
@@ -0,0 +1,94 @@
+package dorklang
+
+import (
+	"os"
+	"path/filepath"
+)
+
+func VetCode(input []byte, options InterpretCodeOptions) (diagnostics DiagnosticCollection, err error) {
+	analysis := newVetAnalysis()
+
+	err = withWorkingDir(options.WorkingDir, func() (err error) {
+		dir, err := os.Getwd()
+		if err != nil {
+			return
+		}
+
+		visiting := make(map[string]bool)
+
+		if options.FilePath != "" {
+			var fileAbsPath string
+
+			fileAbsPath, err = filepath.Abs(options.FilePath)
+			if err != nil {
+				return
+			}
+
+			visiting[fileAbsPath] = true
+		}
+
+		expandable, err := analysis.checkSource(input, options.FilePath, dir, visiting)
+		if err != nil {
+			return
+		}
+
+		var tokens tokenCollection
+
+		if expandable {
+			tokens, err = produceCleanTokens(input, options)
+		} else {
+			tokens, err = produceTokens(input, options.FilePath)
+		}
+		if err != nil {
+			return
+		}
+
+		tr, err := produceTree(tokens, options)
+		if err != nil {
+			return
+		}
+
+		analysis.analyseNodes(tr.rootNode.childNodes, newVetState())
+
+		stackAnalysis, err := analyseStackDepthTree(tr, options)
+		if err != nil {
+			return
+		}
+
+		analysis.diagnostics = append(analysis.diagnostics, stackAnalysis.report().Diagnostics...)
+
+		return
+	})
+	if err != nil {
+		return
+	}
+
+	diagnostics = analysis.diagnostics
+	diagnostics.Sort()
+
+	return
+}
+
+func newVetAnalysis() *vetAnalysis {
+	return &vetAnalysis{
+		diagnosticsSeen: make(map[Diagnostic]bool),
+	}
+}
+
+func newVetState() vetState {
+	return vetState{
+		valueKnown: true,
+	}
+}
+
+func joinVetStates(state, otherState vetState) (output vetState) {
+	output = state
+
+	if !state.valueKnown || !otherState.valueKnown || state.value != otherState.value {
+		output.valueKnown = false
+	}
+
+	output.valueNonZero = state.nonZero() && otherState.nonZero()
+
+	return
+}
@@ -0,0 +1,130 @@
+package dorklang
+
+import (
+	"strings"
+	"testing"
+)
+
+func vetTestMessages(t *testing.T, source string) []string {
+	options := InterpretCodeDefaultOptions.Clone()
+	options.WorkingDir = t.TempDir()
+
+	diagnostics, err := VetCode([]byte(source), options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	messages := make([]string, len(diagnostics))
+	for i, diagnostic := range diagnostics {
+		messages[i] = diagnostic.Message
+	}
+
+	return messages
+}
+
+func TestVetCodeEndlessLoops(t *testing.T) {
+	const (
+		endlessMessage     = "the `<` ... `>` loop can never make the current value zero"
+		unreachableMessage = "unreachable code after a loop that never ends"
+	)
+
+	testCases := []struct {
+		name        string
+		source      string
+		endless     bool
+		unreachable bool
+	}{
+		{"empty body", "+<>", true, false},
+		{"empty body with code after it", "+<> ++", true, true},
+		{"body that leaves the value alone", "+<:>", true, false},
+		{"body that leaves the value alone with code after it", "+<:> ++", true, true},
+		{"body that sets the value", "+< 1 > ++", true, true},
+		{"body that decrements", "++<-> ++", false, false},
+		{"body that sets zero", "+< 0 > ++", false, false},
+		{"body that reads input", "+<?> ++", false, false},
+		{"body with a break", "+< %b > ++", false, false},
+		{"nested loop that ends with a non-zero value", "+< << ? >> > ++", true, true},
+		{"never entered", "< > ++", false, false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			endless, unreachable := false, false
+
+			for _, message := range vetTestMessages(t, testCase.source) {
+				endless = endless || strings.HasPrefix(message, endlessMessage)
+				unreachable = unreachable || message == unreachableMessage
+			}
+
+			if endless != testCase.endless {
+				t.Errorf("expected endless to be %t, got %t", testCase.endless, endless)
+			}
+
+			if unreachable != testCase.unreachable {
+				t.Errorf("expected unreachable to be %t, got %t", testCase.unreachable, unreachable)
+			}
+		})
+	}
+}
+
+func TestVetCodeUndefinedProcedures(t *testing.T) {
+	testCases := []struct {
+		source   string
+		expected []string
+	}{
+		{"%(a + %) &a", nil},
+		{"&a %(a + %)", nil},
+		{"%(a &a %) &a", nil},
+		{"&b", []string{`procedure "b" is never defined`}},
+		{"%(a &c %)", []string{`procedure "c" is never defined`}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.source, func(t *testing.T) {
+			var actual []string
+
+			for _, message := range vetTestMessages(t, testCase.source) {
+				if strings.HasPrefix(message, "procedure ") {
+					actual = append(actual, message)
+				}
+			}
+
+			if strings.Join(actual, "\n") != strings.Join(testCase.expected, "\n") {
+				t.Errorf("expected %q, got %q", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestVetCodeMerges(t *testing.T) {
+	testCases := []struct {
+		source   string
+		expected []string
+	}{
+		{"+++", []string{"`+++` is read as `++` followed by `+`; add whitespace if something else was meant"}},
+		{"++++", []string{"`++++` is read as `++` followed by `++`; add whitespace if something else was meant"}},
+		{"++ +", nil},
+		{"::", nil},
+		{"::::", nil},
+		{";;", nil},
+		{"~~", nil},
+		{"xx", nil},
+		{"rr", nil},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.source, func(t *testing.T) {
+			var actual []string
+
+			for _, message := range vetTestMessages(t, testCase.source) {
+				if strings.Contains(message, " is read as ") {
+					actual = append(actual, message)
+				}
+			}
+
+			if strings.Join(actual, "\n") != strings.Join(testCase.expected, "\n") {
+				t.Errorf("expected %q, got %q", testCase.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,434 @@
+package dorklang
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func (analysis *vetAnalysis) report(span sourceSpan, format string, args ...any) {
+	diagnostic := Diagnostic{
+		Start:   span.start,
+		End:     span.end,
+		Message: fmt.Sprintf(format, args...),
+	}
+
+	if analysis.diagnosticsSeen[diagnostic] {
+		return
+	}
+
+	analysis.diagnosticsSeen[diagnostic] = true
+	analysis.diagnostics = append(analysis.diagnostics, diagnostic)
+}
+
+func (analysis *vetAnalysis) checkSource(source []byte, filePath string, dir string, visiting map[string]bool) (expandable bool, err error) {
+	tokens, err := produceTokens(source, filePath)
+	if err != nil {
+		return
+	}
+
+	expandable = true
+
+	analysis.checkMerges(source, tokens)
+
+	for _, t := range tokens {
+		if t.lex != filePathLexeme || len(t.data) == 0 {
+			continue
+		}
+
+		includePath := string(t.data)
+		if !filepath.IsAbs(includePath) {
+			includePath = filepath.Join(dir, includePath)
+		}
+
+		if _, err2 := os.Stat(includePath); err2 != nil {
+			analysis.report(t.span, "included file %q does not exist", string(t.data))
+			expandable = false
+			continue
+		}
+
+		if filepath.Ext(includePath) != FileExtensionForCode {
+			continue
+		}
+
+		if visiting[includePath] {
+			analysis.report(t.span, "included file %q is already being included, so including it again would never end", string(t.data))
+			expandable = false
+			continue
+		}
+
+		includeSource, err2 := os.ReadFile(includePath)
+		if err2 != nil {
+			analysis.report(t.span, "included file %q cannot be read", string(t.data))
+			expandable = false
+			continue
+		}
+
+		visiting[includePath] = true
+
+		includeExpandable, err2 := analysis.checkSource(includeSource, includePath, filepath.Dir(includePath), visiting)
+		if err2 != nil {
+			analysis.report(t.span, "included file %q cannot be parsed: %v", string(t.data), err2)
+			includeExpandable = false
+		}
+
+		delete(visiting, includePath)
+
+		if !includeExpandable {
+			expandable = false
+		}
+	}
+
+	return
+}
+
+func (analysis *vetAnalysis) checkMerges(source []byte, tokens tokenCollection) {
+	for i := 1; i < len(tokens); i++ {
+		t := tokens[i-1]
+		t2 := tokens[i]
+
+		if t.span.end.Offset != t2.span.start.Offset || t.lex.isSection() || t2.lex.isSection() {
+			continue
+		}
+
+		text := string(source[t.span.start.Offset:t.span.end.Offset])
+		text2 := string(source[t2.span.start.Offset:t2.span.end.Offset])
+
+		if text == "" || text2 == "" || t.lex.sourceText() != text || t2.lex.sourceText() != text2 {
+			continue
+		}
+
+		// only a command that was built by merging runes could have taken the next rune as well
+		if text[len(text)-1] != text2[0] || !t.lex.isMerged() && !t2.lex.isMerged() {
+			continue
+		}
+
+		analysis.report(
+			sourceSpan{start: t.span.start, end: t2.span.end},
+			"`%s%s` is read as `%s` followed by `%s`; add whitespace if something else was meant",
+			text, text2, text, text2,
+		)
+	}
+}
+
+func (analysis *vetAnalysis) analyseNodes(nodes []treeNode, state vetState) vetState {
+	for _, node := range nodes {
+		switch node.getLexeme() {
+		case startCommentSectionLexeme,
+			changeDirLexeme:
+			continue
+		}
+
+		if state.unreachable {
+			if !state.unreachableReported {
+				analysis.report(node.getSpan(), "unreachable code after a loop that never ends")
+				state.unreachableReported = true
+			}
+
+			break
+		}
+
+		state = analysis.analyseNode(node, state)
+	}
+
+	return state
+}
+
+func (analysis *vetAnalysis) analyseNode(node treeNode, state vetState) vetState {
+	switch node := node.(type) {
+	case *parentTreeNode:
+		return analysis.analyseParentNode(node, state)
+	case *terminalTreeNode:
+		return analysis.analyseTerminalNode(node, state)
+	}
+
+	return state
+}
+
+func (analysis *vetAnalysis) analyseParentNode(node *parentTreeNode, state vetState) vetState {
+	switch node.lexeme {
+	case startReadFileSectionLexeme:
+		return analysis.analyseNodes(node.childNodes, state)
+	case startProcedureSectionLexeme:
+		{
+			bodyState := state
+			bodyState.valueKnown = false
+			bodyState.valueNonZero = false
+			bodyState.unreachable = false
+
+			analysis.analyseNodes(node.childNodes, bodyState)
+
+			return state
+		}
+	case startAdditionSectionLexeme,
+		startSubtractionSectionLexeme,
+		startMultiplicationSectionLexeme,
+		startDivisionSectionLexeme,
+		startModuloSectionLexeme:
+		{
+			innerState := state
+			innerState.value = 0
+			innerState.valueKnown = true
+
+			innerState = analysis.analyseNodes(node.childNodes, innerState)
+
+			output := innerState
+			output.value = state.value
+			output.valueKnown = state.valueKnown && innerState.valueKnown
+			output.valueNonZero = false
+
+			if innerState.valueKnown && innerState.value == 0 {
+				switch node.lexeme {
+				case startDivisionSectionLexeme:
+					analysis.report(node.span, "division by zero: the `[[` ... `]]` section always evaluates to 0")
+					output.valueKnown = false
+				case startModuloSectionLexeme:
+					analysis.report(node.span, "division by zero: the `%%[` ... `%%]` section always evaluates to 0")
+					output.valueKnown = false
+				}
+			}
+
+			if output.valueKnown {
+				switch node.lexeme {
+				case startAdditionSectionLexeme:
+					output.value += innerState.value
+				case startSubtractionSectionLexeme:
+					output.value -= innerState.value
+				case startMultiplicationSectionLexeme:
+					output.value *= innerState.value
+				case startDivisionSectionLexeme:
+					output.value /= innerState.value
+				case startModuloSectionLexeme:
+					output.value %= innerState.value
+				}
+			}
+
+			return output
+		}
+	case startJumpIfPositiveSectionLexeme:
+		{
+			if state.valueKnown && state.value == 0 {
+				return state
+			}
+
+			entered := state.nonZero()
+
+			// the body is only run while the value is non-zero, so it starts from every non-zero value at once
+			bodyState := state
+			bodyState.valueKnown = false
+			bodyState.valueNonZero = true
+
+			bodyState, jumps := analysis.analyseLoopBody(node, bodyState)
+
+			endless := !jumps.breakFound && bodyState.nonZero() &&
+				(!jumps.continueFound || jumps.continueState.nonZero())
+			if endless {
+				analysis.report(node.span, "the `<` ... `>` loop can never make the current value zero, so it never ends once entered")
+			}
+
+			output := joinVetStates(state, bodyState)
+			output.value = 0
+			output.valueKnown = !jumps.breakFound
+			output.valueNonZero = false
+			output.unreachable = entered && (endless || bodyState.unreachable)
+			output.unreachableReported = bodyState.unreachableReported
+
+			return output
+		}
+	case startJumpIfZeroSectionLexeme:
+		{
+			if state.valueKnown && state.value != 0 {
+				return state
+			}
+
+			entered := state.valueKnown
+
+			bodyState := state
+			bodyState.value = 0
+			bodyState.valueKnown = true
+
+			bodyState, jumps := analysis.analyseLoopBody(node, bodyState)
+
+			endless := !jumps.breakFound && bodyState.valueKnown && bodyState.value == 0 &&
+				(!jumps.continueFound || jumps.continueState.valueKnown && jumps.continueState.value == 0)
+			if endless {
+				analysis.report(node.span, "the `<<` ... `>>` loop can never make the current value non-zero, so it never ends once entered")
+			}
+
+			output := joinVetStates(state, bodyState)
+			output.valueKnown = false
+			output.valueNonZero = !jumps.breakFound
+			output.unreachable = entered && (endless || bodyState.unreachable)
+			output.unreachableReported = bodyState.unreachableReported
+
+			return output
+		}
+	case startIfSectionLexeme,
+		startIfZeroSectionLexeme:
+		{
+			bodyNodes, elseNode := node.ifBranches()
+
+			if state.valueKnown {
+				if (state.value != 0) == (node.lexeme == startIfSectionLexeme) {
+					return analysis.analyseNodes(bodyNodes, state)
+				}
+
+				if elseNode == nil {
+					return state
+				}
+
+				return analysis.analyseNodes(elseNode.childNodes, state)
+			}
+
+			bodyState := state
+			elseState := state
+			if node.lexeme == startIfSectionLexeme {
+				bodyState.valueNonZero = true
+				elseState.value = 0
+				elseState.valueKnown = true
+			} else {
+				bodyState.value = 0
+				bodyState.valueKnown = true
+				elseState.valueNonZero = true
+			}
+
+			bodyState = analysis.analyseNodes(bodyNodes, bodyState)
+
+			if elseNode != nil {
+				elseState.unreachableReported = bodyState.unreachableReported
+				elseState = analysis.analyseNodes(elseNode.childNodes, elseState)
+			}
+
+			output := joinVetStates(bodyState, elseState)
+			output.unreachable = bodyState.unreachable && elseState.unreachable
+			output.unreachableReported = elseState.unreachableReported
+
+			return output
+		}
+	}
+
+	return state
+}
+
+// a value that is not known can still be known to be non-zero, e.g. inside a loop or an if section
+func (state vetState) nonZero() bool {
+	if state.valueKnown {
+		return state.value != 0
+	}
+
+	return state.valueNonZero
+}
+
+func (analysis *vetAnalysis) analyseLoopBody(node *parentTreeNode, state vetState) (output vetState, jumps *vetLoopJumps) {
+	jumps = &vetLoopJumps{}
+
+	analysis.loops = append(analysis.loops, jumps)
+	output = analysis.analyseNodes(node.childNodes, state)
+	analysis.loops = analysis.loops[:len(analysis.loops)-1]
+
+	return
+}
+
+func (analysis *vetAnalysis) analyseTerminalNode(node *terminalTreeNode, state vetState) vetState {
+	switch {
+	case node.lexeme.isPureValueCommand():
+		state.valueNonZero = false
+
+		if state.valueKnown {
+			if value, err := node.value(state.value); err == nil {
+				state.value = value
+			} else {
+				state.valueKnown = false
+			}
+		}
+	case node.lexeme.isConstantValueCommand():
+		state.valueNonZero = false
+
+		if value, err := node.value(0); err == nil {
+			state.value = value
+			state.valueKnown = true
+		} else {
+			state.valueKnown = false
+		}
+	}
+
+	switch node.lexeme {
+	case setRandomByteLexeme,
+		setRandomMaxLexeme,
+		setSecondTimestampLexeme,
+		setNanosecondTimestampLexeme,
+		inputCharacterLexeme,
+		inputNumberLexeme,
+		hashStackOneByteLexeme,
+		hashStackEightByteLexeme,
+		addStackPairLexeme,
+		subtractStackPairLexeme,
+		multiplyStackPairLexeme,
+		divideStackPairLexeme,
+		moduloStackPairLexeme,
+		addStackWholeLexeme,
+		subtractStackWholeLexeme,
+		multiplyStackWholeLexeme,
+		divideStackWholeLexeme,
+		moduloStackWholeLexeme,
+		bitwiseAndStackPairLexeme,
+		bitwiseAndStackWholeLexeme,
+		bitwiseOrStackPairLexeme,
+		bitwiseOrStackWholeLexeme,
+		bitwiseXorStackPairLexeme,
+		bitwiseXorStackWholeLexeme,
+		shiftLeftStackLexeme,
+		shiftRightStackLexeme,
+		logicalAndStackPairLexeme,
+		logicalAndStackWholeLexeme,
+		logicalOrStackPairLexeme,
+		logicalOrStackWholeLexeme,
+		logicalXorStackPairLexeme,
+		logicalXorStackWholeLexeme,
+		equalStackPairLexeme,
+		notEqualStackPairLexeme,
+		lessStackPairLexeme,
+		greaterStackPairLexeme,
+		lessOrEqualStackPairLexeme,
+		greaterOrEqualStackPairLexeme,
+		popStackLastLexeme,
+		popStackRandomLexeme,
+		peekStackTopLexeme,
+		countStackLexeme:
+		state.valueKnown = false
+		state.valueNonZero = false
+	case resetStateLexeme:
+		state.value = 0
+		state.valueKnown = true
+		state.valueNonZero = false
+	case filePathLexeme:
+		if filepath.Ext(string(node.data)) == FileExtensionForCode {
+			state.valueKnown = false
+			state.valueNonZero = false
+		}
+	case breakLoopLexeme:
+		if len(analysis.loops) > 0 {
+			analysis.loops[len(analysis.loops)-1].breakFound = true
+		}
+	case callProcedureLexeme:
+		if _, found := node.tree.procedures[string(node.data)]; !found {
+			analysis.report(node.span, "procedure %q is never defined", string(node.data))
+		}
+
+		state.valueKnown = false
+		state.valueNonZero = false
+	case continueLoopLexeme:
+		if len(analysis.loops) > 0 {
+			jumps := analysis.loops[len(analysis.loops)-1]
+
+			if jumps.continueFound {
+				jumps.continueState = joinVetStates(jumps.continueState, state)
+			} else {
+				jumps.continueState = state
+				jumps.continueFound = true
+			}
+		}
+	}
+
+	return state
+}